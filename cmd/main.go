@@ -3,7 +3,9 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"github.com/open-and-sustainable/prismaid/config"
 	terminal "github.com/open-and-sustainable/prismaid/init"
 	"github.com/open-and-sustainable/prismaid"
 )
@@ -11,8 +13,15 @@ import (
 // Main function
 func main() {
 	// Define flags for the project configuration file and the init option
-	projectConfigPath := flag.String("project", "", "Path to the project configuration file")
+	projectConfigPath := flag.String("project", "", "Path to the project configuration file, or - to read it from stdin")
 	initFlag := flag.Bool("init", false, "Run interactively to initialize a new project configuration file")
+	migratePath := flag.String("migrate", "", "Path to a project configuration file to upgrade to the current format")
+	quietFlag := flag.Bool("quiet", false, "Suppress log output, overriding the log_level of the project configuration")
+	verboseFlag := flag.Bool("verbose", false, "Save detailed logs to file, overriding the log_level of the project configuration")
+	convertOutput := flag.String("convert-output", "", "Directory receiving the text files converted from the input files, which are then reviewed from there")
+	convertPptx := flag.Bool("convert-pptx", false, "Convert the PPTX files in the input directory, in addition to the formats in input_conversion")
+	convertRtf := flag.Bool("convert-rtf", false, "Convert the RTF files in the input directory, in addition to the formats in input_conversion")
+	zoteroSummary := flag.Bool("zotero-summary", false, "Save the record of the Zotero download as zotero_download.json in the results directory")
 
 	// Parse the flags
 	flag.Parse()
@@ -24,21 +33,50 @@ func main() {
 	}
 
 	// Check if both flags are missing or both are present, which could be an invalid state
-	if *projectConfigPath == "" && !*initFlag {
-		fmt.Println("Usage: ./prismAId_OS_CPU[.exe] --project <path-to-your-project-config.toml> or --init")
+	if *projectConfigPath == "" && !*initFlag && *migratePath == "" {
+		fmt.Println("Usage: ./prismAId_OS_CPU[.exe] --project <path-to-your-project-config.toml>, --init, or --migrate <path-to-your-project-config.toml>")
 		os.Exit(1)
 	}
 
+	// Verbosity flags override the log level of the project configuration
+	if *quietFlag && *verboseFlag {
+		fmt.Println("The -quiet and -verbose flags cannot be used together")
+		os.Exit(1)
+	}
+	logLevel := ""
+	if *quietFlag {
+		logLevel = "low"
+	} else if *verboseFlag {
+		logLevel = "high"
+	}
+
+	// Handle migrate logic if -migrate flag is provided
+	if *migratePath != "" {
+		err := migrateConfig(*migratePath)
+		if err != nil {
+			fmt.Println("Error migrating project configuration:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Handle project logic if -project flag is provided
 	if *projectConfigPath != "" {
-		// Read the file using the injected FileReader interface
-		data, err := os.ReadFile(*projectConfigPath)
+		// Read the configuration from file, or from stdin when the path is "-"
+		data, err := readProjectConfig(*projectConfigPath)
 		if err != nil {
 			fmt.Println("Error reading Review configuration:", err)
 			os.Exit(1)
 		}
 
-		err = prismaid.RunReview(string(data))
+		var convertFormats []string
+		if *convertPptx {
+			convertFormats = append(convertFormats, "pptx")
+		}
+		if *convertRtf {
+			convertFormats = append(convertFormats, "rtf")
+		}
+		err = prismaid.RunReviewWithOptions(string(data), prismaid.ReviewOptions{LogLevel: logLevel, ConvertOutputDir: *convertOutput, ZoteroSummary: *zoteroSummary, ConvertFormats: convertFormats})
 		if err != nil {
 			fmt.Println("Error running Review logic:", err)
 			os.Exit(1)
@@ -51,4 +89,47 @@ func main() {
 		terminal.RunInteractiveConfigCreation()
 		return
 	}
-}
\ No newline at end of file
+}
+
+// readProjectConfig reads the project configuration from the file at path, or from stdin when path is "-"
+func readProjectConfig(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// migrateConfig upgrades the configuration file at path to the current format, keeping a copy of
+// the original file with the .bak extension appended
+func migrateConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	upgraded, changes, warnings, err := config.Migrate(string(data))
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		fmt.Println("-", change)
+	}
+	for _, warning := range warnings {
+		fmt.Println("- warning:", warning)
+	}
+	if len(changes) == 0 {
+		fmt.Println("The project configuration is already in the current format.")
+		return nil
+	}
+
+	backupPath := path + ".bak"
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(upgraded), 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("Project configuration upgraded, the original file is saved as %s\n", backupPath)
+	return nil
+}
@@ -44,6 +44,11 @@ type ProjectConfiguration struct {
 	CotJustification string  `toml:"cot_justification"`
 	Duplication      string  `toml:"duplication"`
 	Summary    string     `toml:"summary"`
+	PptxNotes     string `toml:"pptx_notes"`
+	MaxPages      int    `toml:"max_pages"`
+	PageMarkers   string `toml:"page_markers"`
+	ExtractTables string `toml:"extract_tables"`
+	ExtractLinks  string `toml:"extract_links"`
 }
 
 // ProjectZotero defines various settings related to the collection or group to be reviewed.
@@ -51,6 +56,8 @@ type ProjectZotero struct {
 	User  string `toml:"user"`
 	API string `toml:"api_key"`
 	Group string `toml:"group"`
+	DownloadSummary string `toml:"download_summary"`
+	AuthMode string `toml:"auth_mode"`
 }
 
 // LLMConfig holds the configuration settings specific to the AI model being used.
@@ -93,13 +100,17 @@ type ReviewItem struct {
 //   - An error if the TOML data cannot be decoded or any other processing error occurs.
 //
 // The function handles the following:
-//   1. Decoding the TOML configuration into the Config structure.
+//   1. Decoding the TOML configuration into the Config structure, and resolving the ${ENV_VAR}
+//      references in API keys and directories from environment variables.
 //   2. Checking for missing API keys and attempting to retrieve them from environment variables 
 //      based on the provider (OpenAI, GoogleAI, Cohere, Anthropic).
 //   3. Setting default values for missing or invalid configuration fields, such as 
 //      InputConversion, OutputFormat, LogLevel, CotJustification, Summary, and Duplication.
 //   4. Ensuring that LLM configuration parameters like Temperature, TpmLimit, and RpmLimit are 
-//      non-negative by applying minimum value constraints.
+//      non-negative by applying minimum value constraints. Temperatures above the maximum accepted
+//      by the provider are checked later with CheckTemperatures, once logging is set up.
+//
+// The configuration is not checked with Validate, since only the review requires models in [project.llm].
 func LoadConfig(tomlConfiguration string, envReader EnvReader) (*Config, error) {
 	var config Config
 
@@ -108,6 +119,11 @@ func LoadConfig(tomlConfiguration string, envReader EnvReader) (*Config, error)
         return nil, err
     }
 
+	// Resolve ${ENV_VAR} references before looking for missing API keys
+	if err := expandEnvFields(&config, envReader); err != nil {
+		return nil, err
+	}
+
 	for key, llm := range config.Project.LLM {
 		if llm.ApiKey == "" {  // If API key is empty, look for it in environment variables
 			switch llm.Provider {
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// envReferenceRegex matches the ${ENV_VAR} references resolved when loading the configuration.
+var envReferenceRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces each ${ENV_VAR} reference in value with the content of the environment variable,
+// leaving values without references untouched. It returns an error naming the first variable that is
+// not set or empty, so that a missing key or directory is not silently replaced by an empty string.
+func expandEnv(value string, envReader EnvReader) (string, error) {
+	var missing string
+	expanded := envReferenceRegex.ReplaceAllStringFunc(value, func(reference string) string {
+		name := envReferenceRegex.FindStringSubmatch(reference)[1]
+		resolved := envReader.GetEnv(name)
+		if resolved == "" && missing == "" {
+			missing = name
+		}
+		return resolved
+	})
+	if missing != "" {
+		return "", fmt.Errorf("environment variable %s referenced in the configuration is not set", missing)
+	}
+	return expanded, nil
+}
+
+// expandEnvFields resolves the ${ENV_VAR} references in the API keys and directories of the configuration.
+func expandEnvFields(config *Config, envReader EnvReader) error {
+	fields := []*string{
+		&config.Project.Configuration.InputDirectory,
+		&config.Project.Configuration.ResultsFileName,
+		&config.Project.Zotero.API,
+	}
+	for _, field := range fields {
+		expanded, err := expandEnv(*field, envReader)
+		if err != nil {
+			return err
+		}
+		*field = expanded
+	}
+
+	for key, llm := range config.Project.LLM {
+		expanded, err := expandEnv(llm.ApiKey, envReader)
+		if err != nil {
+			return fmt.Errorf("[project.llm.%s]: %w", key, err)
+		}
+		llm.ApiKey = expanded
+		config.Project.LLM[key] = llm
+	}
+	return nil
+}
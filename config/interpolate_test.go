@@ -0,0 +1,92 @@
+package config
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+    envReader := &MockEnvReader{
+        values: map[string]string{
+            "MY_KEY":   "secret",
+            "BASE_DIR": "/data",
+        },
+    }
+
+    tests := []struct {
+        name     string
+        value    string
+        expected string
+        errVar   string
+    }{
+        {"literal value", "plain-key", "plain-key", ""},
+        {"empty value", "", "", ""},
+        {"dollar without braces", "$MY_KEY", "$MY_KEY", ""},
+        {"whole value", "${MY_KEY}", "secret", ""},
+        {"embedded reference", "${BASE_DIR}/papers", "/data/papers", ""},
+        {"unset variable", "${MISSING_KEY}", "", "MISSING_KEY"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := expandEnv(tt.value, envReader)
+            if tt.errVar != "" {
+                if err == nil || !strings.Contains(err.Error(), tt.errVar) {
+                    t.Fatalf("expandEnv() error = %v, want error naming %s", err, tt.errVar)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("expandEnv() returned an unexpected error: %v", err)
+            }
+            if got != tt.expected {
+                t.Errorf("expandEnv() = %q, want %q", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestLoadConfigEnvInterpolation(t *testing.T) {
+    tomlContent := `
+[project.configuration]
+input_directory = "${BASE_DIR}/txt"
+results_file_name = "/literal/results"
+
+[project.zotero]
+api_key = "${ZOTERO_KEY}"
+
+[project.llm.1]
+provider = "OpenAI"
+api_key = "${PROJECT_OPENAI_KEY}"
+model = "gpt-4o-mini"
+`
+    envReader := &MockEnvReader{
+        values: map[string]string{
+            "BASE_DIR":           "/data",
+            "ZOTERO_KEY":         "zotero-secret",
+            "PROJECT_OPENAI_KEY": "openai-secret",
+        },
+    }
+
+    config, err := LoadConfig(tomlContent, envReader)
+    if err != nil {
+        t.Fatalf("LoadConfig returned an unexpected error: %v", err)
+    }
+    if got := config.Project.Configuration.InputDirectory; got != "/data/txt" {
+        t.Errorf("input_directory = %q, want %q", got, "/data/txt")
+    }
+    if got := config.Project.Configuration.ResultsFileName; got != "/literal/results" {
+        t.Errorf("results_file_name = %q, want %q", got, "/literal/results")
+    }
+    if got := config.Project.Zotero.API; got != "zotero-secret" {
+        t.Errorf("zotero api_key = %q, want %q", got, "zotero-secret")
+    }
+    if got := config.Project.LLM["1"].ApiKey; got != "openai-secret" {
+        t.Errorf("llm api_key = %q, want %q", got, "openai-secret")
+    }
+
+    delete(envReader.values, "PROJECT_OPENAI_KEY")
+    if _, err := LoadConfig(tomlContent, envReader); err == nil || !strings.Contains(err.Error(), "PROJECT_OPENAI_KEY") {
+        t.Errorf("LoadConfig error = %v, want error naming PROJECT_OPENAI_KEY", err)
+    }
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+// migrationDefault is a field added to the configuration format, with the value filled in by Migrate.
+type migrationDefault struct {
+	key   string
+	value interface{}
+}
+
+var (
+	configurationDefaults = []migrationDefault{
+		{"input_conversion", ""},
+		{"output_format", "csv"},
+		{"log_level", "low"},
+		{"duplication", "no"},
+		{"cot_justification", "no"},
+		{"summary", "no"},
+	}
+	zoteroDefaults = []migrationDefault{
+		{"user", ""},
+		{"api_key", ""},
+		{"group", ""},
+		{"download_summary", "no"},
+		{"auth_mode", "api_key"},
+	}
+	llmDefaults = []migrationDefault{
+		{"api_key", ""},
+		{"tpm_limit", int64(0)},
+		{"rpm_limit", int64(0)},
+	}
+	promptDefaults = []migrationDefault{
+		{"persona", ""},
+		{"task", ""},
+		{"expected_result", ""},
+		{"failsafe", ""},
+		{"definitions", ""},
+		{"example", ""},
+	}
+)
+
+// Migrate upgrades a TOML configuration written for an older version of prismAId to the current format.
+//
+// Parameters:
+//   - oldTOML: A string containing the TOML configuration to upgrade.
+//
+// Returns:
+//   - The upgraded TOML configuration. Comments and formatting of the original are not preserved.
+//   - The list of changes applied.
+//   - The list of warnings about problems that need manual intervention, such as missing models or
+//     keys not used by prismAId.
+//   - An error if the TOML data cannot be decoded or encoded.
+//
+// The function handles the following:
+//   1. Moving a single model defined directly in [project.llm] to [project.llm.1].
+//   2. Adding the fields introduced in newer versions with their default values to the sections of the
+//      original configuration. Missing sections, such as [project.zotero], are not added, since LoadConfig
+//      applies the same defaults when they are absent.
+//   3. Reporting keys that are not part of the current format.
+func Migrate(oldTOML string) (string, []string, []string, error) {
+	var data map[string]interface{}
+	if _, err := toml.Decode(oldTOML, &data); err != nil {
+		return "", nil, nil, err
+	}
+
+	var changes []string
+	project := table(data, "project")
+	addDefaults(table(project, "configuration"), configurationDefaults, "project.configuration", &changes)
+	addDefaults(table(project, "zotero"), zoteroDefaults, "project.zotero", &changes)
+
+	llms := table(project, "llm")
+	if _, ok := llms["provider"]; ok {
+		project["llm"] = map[string]interface{}{"1": llms}
+		llms = project["llm"].(map[string]interface{})
+		changes = append(changes, "moved the model defined in [project.llm] to [project.llm.1]")
+	}
+	keys := make([]string, 0, len(llms))
+	for key := range llms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if llm, ok := llms[key].(map[string]interface{}); ok {
+			addDefaults(llm, llmDefaults, "project.llm."+key, &changes)
+		}
+	}
+
+	addDefaults(table(data, "prompt"), promptDefaults, "prompt", &changes)
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(data); err != nil {
+		return "", nil, nil, err
+	}
+	newTOML := buf.String()
+
+	// Report what the current format does not support
+	var config Config
+	meta, err := toml.Decode(newTOML, &config)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	var warnings []string
+	if len(config.Project.LLM) == 0 {
+		warnings = append(warnings, ErrNoModel.Error())
+	}
+	for _, key := range meta.Undecoded() {
+		warnings = append(warnings, fmt.Sprintf("key %s is not used by prismAId", key.String()))
+	}
+
+	return newTOML, changes, warnings, nil
+}
+
+// table returns the table stored in parent under key, or nil if parent has no such table
+func table(parent map[string]interface{}, key string) map[string]interface{} {
+	table, _ := parent[key].(map[string]interface{})
+	return table
+}
+
+// addDefaults sets the default value of each field missing from table, leaving a missing table out
+func addDefaults(table map[string]interface{}, defaults []migrationDefault, path string, changes *[]string) {
+	if table == nil {
+		return
+	}
+	for _, d := range defaults {
+		if _, ok := table[d.key]; !ok {
+			table[d.key] = d.value
+			*changes = append(*changes, fmt.Sprintf("added %s.%s = %#v", path, d.key, d.value))
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestMigrate(t *testing.T) {
+    oldTOML := `
+[project]
+name = "Old project"
+
+[project.configuration]
+input_directory = "/path/to/txt"
+results_file_name = "/path/to/results"
+output_format = "json"
+old_option = "yes"
+
+[project.llm]
+provider = "OpenAI"
+model = "gpt-4o-mini"
+temperature = 0.2
+
+[prompt]
+persona = "You are a scientist."
+task = "Map the concepts."
+
+[review]
+[review.1]
+key = "interest rate"
+values = [""]
+`
+    newTOML, changes, warnings, err := Migrate(oldTOML)
+    if err != nil {
+        t.Fatalf("Migrate returned an unexpected error: %v", err)
+    }
+
+    config, err := LoadConfig(newTOML, &MockEnvReader{values: map[string]string{"OPENAI_API_KEY": "key"}})
+    if err != nil {
+        t.Fatalf("LoadConfig failed on the migrated configuration: %v\n%s", err, newTOML)
+    }
+    llm, ok := config.Project.LLM["1"]
+    if !ok || llm.Provider != "OpenAI" || llm.Model != "gpt-4o-mini" || llm.Temperature != 0.2 {
+        t.Errorf("Expected the model to be moved to [project.llm.1], got %+v", config.Project.LLM)
+    }
+    if config.Project.Configuration.OutputFormat != "json" {
+        t.Errorf("Expected output_format to be preserved, got %q", config.Project.Configuration.OutputFormat)
+    }
+    if config.Prompt.Persona != "You are a scientist." {
+        t.Errorf("Expected persona to be preserved, got %q", config.Prompt.Persona)
+    }
+    if strings.Contains(newTOML, "zotero") {
+        t.Errorf("Did not expect a [project.zotero] section in the migrated configuration:\n%s", newTOML)
+    }
+
+    expectedChanges := []string{
+        "moved the model defined in [project.llm] to [project.llm.1]",
+        `added project.configuration.summary = "no"`,
+        "added project.llm.1.tpm_limit = 0",
+        `added prompt.failsafe = ""`,
+    }
+    joined := strings.Join(changes, "\n")
+    for _, expected := range expectedChanges {
+        if !strings.Contains(joined, expected) {
+            t.Errorf("Expected change %q, got:\n%s", expected, joined)
+        }
+    }
+    expectedWarnings := []string{"key project.configuration.old_option is not used by prismAId"}
+    if strings.Join(warnings, "\n") != strings.Join(expectedWarnings, "\n") {
+        t.Errorf("Expected warnings %v, got %v", expectedWarnings, warnings)
+    }
+    if strings.Contains(joined, "old_option") {
+        t.Errorf("Did not expect warnings among the changes:\n%s", joined)
+    }
+    if strings.Contains(joined, "output_format") {
+        t.Errorf("Did not expect a change for the existing output_format:\n%s", joined)
+    }
+}
+
+func TestMigrateCurrentConfig(t *testing.T) {
+    oldTOML := `
+[project.configuration]
+input_conversion = ""
+output_format = "csv"
+log_level = "low"
+duplication = "no"
+cot_justification = "no"
+summary = "no"
+
+[project.zotero]
+user = ""
+api_key = ""
+group = ""
+download_summary = "no"
+auth_mode = "api_key"
+
+[project.llm.1]
+provider = "Cohere"
+api_key = ""
+model = "command-r"
+tpm_limit = 0
+rpm_limit = 0
+
+[prompt]
+persona = ""
+task = ""
+expected_result = ""
+failsafe = ""
+definitions = ""
+example = ""
+`
+    _, changes, warnings, err := Migrate(oldTOML)
+    if err != nil {
+        t.Fatalf("Migrate returned an unexpected error: %v", err)
+    }
+    if len(changes) != 0 || len(warnings) != 0 {
+        t.Errorf("Expected no changes or warnings for a current configuration, got %v and %v", changes, warnings)
+    }
+}
+
+func TestMigrateZotero(t *testing.T) {
+    oldTOML := `
+[project.zotero]
+user = "123"
+api_key = "key"
+group = "Collection"
+
+[project.llm.1]
+provider = "Cohere"
+model = "command-r"
+`
+    _, changes, _, err := Migrate(oldTOML)
+    if err != nil {
+        t.Fatalf("Migrate returned an unexpected error: %v", err)
+    }
+    expectedChanges := []string{
+        `added project.zotero.download_summary = "no"`,
+        `added project.zotero.auth_mode = "api_key"`,
+        "added project.llm.1.api_key = \"\"",
+    }
+    joined := strings.Join(changes, "\n")
+    for _, expected := range expectedChanges {
+        if !strings.Contains(joined, expected) {
+            t.Errorf("Expected change %q, got:\n%s", expected, joined)
+        }
+    }
+    if strings.Contains(joined, "prompt") || strings.Contains(joined, "configuration") {
+        t.Errorf("Did not expect changes to sections missing from the configuration:\n%s", joined)
+    }
+}
+
+func TestMigrateNoModel(t *testing.T) {
+    _, _, warnings, err := Migrate(`[project]
+name = "No model"`)
+    if err != nil {
+        t.Fatalf("Migrate returned an unexpected error: %v", err)
+    }
+    if len(warnings) != 1 || warnings[0] != ErrNoModel.Error() {
+        t.Errorf("Expected a warning for the missing models, got %v", warnings)
+    }
+}
+
+func TestMigrateInvalidTOML(t *testing.T) {
+    if _, _, _, err := Migrate("[project"); err == nil {
+        t.Error("Expected an error for invalid TOML")
+    }
+}
@@ -0,0 +1,35 @@
+package config
+
+// providerModels lists the models supported by each provider, in the order presented to the user, and among
+// them the models that do not support setting the temperature, such as reasoning models.
+var providerModels = []struct {
+	provider      string
+	models        []string
+	noTemperature []string
+}{
+	{"OpenAI", []string{"gpt-3.5-turbo", "gpt-4-turbo", "gpt-4o", "gpt-4o-mini"}, nil},
+	{"GoogleAI", []string{"gemini-1.0-pro", "gemini-1.5-pro", "gemini-1.5-flash"}, nil},
+	{"Cohere", []string{"command", "command-light", "command-r", "command-r-plus"}, nil},
+	{"Anthropic", []string{"claude-3-haiku", "claude-3-sonnet", "claude-3-opus", "claude-3-5-haiku", "claude-3-5-sonnet"}, nil},
+}
+
+// SupportedProviders returns the names of the supported AI providers, as used in the provider field of [project.llm.N].
+func SupportedProviders() []string {
+	providers := make([]string, len(providerModels))
+	for i, p := range providerModels {
+		providers[i] = p.provider
+	}
+	return providers
+}
+
+// ModelsForProvider returns the models supported for the provider, as used in the model field of [project.llm.N],
+// or nil if the provider is not supported. An empty model field, selecting the cheapest model automatically,
+// is always accepted in addition to the returned models.
+func ModelsForProvider(provider string) []string {
+	for _, p := range providerModels {
+		if p.provider == provider {
+			return append([]string(nil), p.models...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestSupportedProviders(t *testing.T) {
+    expected := []string{"OpenAI", "GoogleAI", "Cohere", "Anthropic"}
+    if got := SupportedProviders(); !reflect.DeepEqual(got, expected) {
+        t.Errorf("SupportedProviders() = %v, want %v", got, expected)
+    }
+}
+
+func TestModelsForProvider(t *testing.T) {
+    for _, provider := range SupportedProviders() {
+        if len(ModelsForProvider(provider)) == 0 {
+            t.Errorf("ModelsForProvider(%q) returned no models", provider)
+        }
+    }
+    if models := ModelsForProvider("Unknown"); models != nil {
+        t.Errorf("ModelsForProvider(\"Unknown\") = %v, want nil", models)
+    }
+
+    // The returned slice must not alias the internal list
+    models := ModelsForProvider("OpenAI")
+    models[0] = "changed"
+    if ModelsForProvider("OpenAI")[0] == "changed" {
+        t.Error("ModelsForProvider returned the internal list")
+    }
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// defaultMaxTemperature is the maximum temperature assumed for providers not listed in maxTemperatures.
+const defaultMaxTemperature = 1.0
+
+// maxTemperatures holds the maximum temperature accepted by each provider API.
+var maxTemperatures = map[string]float64{
+	"OpenAI":    2,
+	"GoogleAI":  2,
+	"Cohere":    1,
+	"Anthropic": 1,
+}
+
+// MaxTemperature returns the maximum temperature accepted by the provider.
+func MaxTemperature(provider string) float64 {
+	if max, ok := maxTemperatures[provider]; ok {
+		return max
+	}
+	return defaultMaxTemperature
+}
+
+// IgnoresTemperature reports whether the model does not support setting the temperature, as listed
+// with the models returned by ModelsForProvider.
+func IgnoresTemperature(provider, model string) bool {
+	for _, p := range providerModels {
+		if p.provider != provider {
+			continue
+		}
+		for _, m := range p.noTemperature {
+			if m == model {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckTemperature checks the temperature of a model against the range accepted by its provider.
+//
+// Returns:
+//   - The temperature to use, lowered to the provider maximum when above it.
+//   - A warning describing the adjustment or the problem, or an empty string if the temperature is valid.
+func CheckTemperature(provider, model string, temperature float64) (float64, string) {
+	if IgnoresTemperature(provider, model) {
+		if temperature != 0 {
+			return temperature, fmt.Sprintf("model %s does not support the temperature setting, the value %g is ignored", model, temperature)
+		}
+		return temperature, ""
+	}
+	if max := MaxTemperature(provider); temperature > max {
+		return max, fmt.Sprintf("temperature %g is above the maximum %g accepted by %s, using %g", temperature, max, provider, max)
+	}
+	return temperature, ""
+}
+
+// CheckTemperatures checks the temperature of each model in [project.llm] with CheckTemperature, lowering
+// the temperatures above the provider maximum in place.
+//
+// Returns:
+//   - The warnings of CheckTemperature, prefixed with the [project.llm.N] section of the model.
+func CheckTemperatures(config *Config) []string {
+	keys := make([]string, 0, len(config.Project.LLM))
+	for key := range config.Project.LLM {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var warnings []string
+	for _, key := range keys {
+		llm := config.Project.LLM[key]
+		temperature, warning := CheckTemperature(llm.Provider, llm.Model, llm.Temperature)
+		if warning == "" {
+			continue
+		}
+		llm.Temperature = temperature
+		config.Project.LLM[key] = llm
+		warnings = append(warnings, fmt.Sprintf("[project.llm.%s] %s", key, warning))
+	}
+	return warnings
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestCheckTemperature(t *testing.T) {
+    tests := []struct {
+        name        string
+        provider    string
+        model       string
+        temperature float64
+        expected    float64
+        warning     bool
+    }{
+        {"OpenAI within range", "OpenAI", "gpt-4o-mini", 1.5, 1.5, false},
+        {"OpenAI above range", "OpenAI", "gpt-4o-mini", 2.5, 2, true},
+        {"GoogleAI within range", "GoogleAI", "gemini-1.5-flash", 2, 2, false},
+        {"Anthropic above range", "Anthropic", "claude-3-haiku", 1.2, 1, true},
+        {"Cohere within range", "Cohere", "command-r", 0.01, 0.01, false},
+        {"unknown provider", "Other", "model", 1.5, 1, true},
+        {"reasoning model", "Reasoning", "reasoner", 0.7, 0.7, true},
+        {"reasoning model default", "Reasoning", "reasoner", 0, 0, false},
+    }
+
+    // IgnoresTemperature reads the models without temperature from the supported models
+    supported := providerModels
+    defer func() { providerModels = supported }()
+    reasoning := providerModels[0]
+    reasoning.provider, reasoning.models, reasoning.noTemperature = "Reasoning", []string{"reasoner"}, []string{"reasoner"}
+    providerModels = append(supported[:len(supported):len(supported)], reasoning)
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            temperature, warning := CheckTemperature(tt.provider, tt.model, tt.temperature)
+            if temperature != tt.expected {
+                t.Errorf("CheckTemperature() temperature = %g, want %g", temperature, tt.expected)
+            }
+            if (warning != "") != tt.warning {
+                t.Errorf("CheckTemperature() warning = %q, want warning %v", warning, tt.warning)
+            }
+        })
+    }
+}
+
+func TestCheckTemperatures(t *testing.T) {
+    config := &Config{Project: ProjectConfig{LLM: map[string]LLMItem{
+        "1": {Provider: "OpenAI", Model: "gpt-4o-mini", Temperature: 1.5},
+        "2": {Provider: "Anthropic", Model: "claude-3-haiku", Temperature: 1.2},
+    }}}
+
+    warnings := CheckTemperatures(config)
+    if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "[project.llm.2] ") {
+        t.Errorf("Expected one warning for [project.llm.2], got %v", warnings)
+    }
+    if config.Project.LLM["1"].Temperature != 1.5 || config.Project.LLM["2"].Temperature != 1 {
+        t.Errorf("Expected only the Anthropic temperature to be lowered, got %+v", config.Project.LLM)
+    }
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoModel is returned by Validate when the configuration does not define any model in [project.llm].
+var ErrNoModel = errors.New("no model configured: add at least one [project.llm.N] section with provider and model")
+
+// Validate checks a loaded configuration for settings that would make the review fail, reporting
+// them before any file is converted or any model is queried.
+//
+// Parameters:
+//   - config: A pointer to the Config returned by LoadConfig.
+//
+// Returns:
+//   - An error joining all the problems found, or nil if the configuration is valid.
+func Validate(config *Config) error {
+	var errs []error
+
+	if len(config.Project.LLM) == 0 {
+		errs = append(errs, ErrNoModel)
+	}
+	for key, llm := range config.Project.LLM {
+		if llm.Provider == "" {
+			errs = append(errs, fmt.Errorf("[project.llm.%s]: provider is missing", key))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+    "errors"
+    "strings"
+    "testing"
+)
+
+func TestValidate(t *testing.T) {
+    tests := []struct {
+        name      string
+        llm       map[string]LLMItem
+        wantErr   error
+        errSubstr string
+    }{
+        {
+            name: "valid",
+            llm:  map[string]LLMItem{"1": {Provider: "OpenAI", Model: "gpt-4o-mini"}},
+        },
+        {
+            name:    "no model",
+            llm:     map[string]LLMItem{},
+            wantErr: ErrNoModel,
+        },
+        {
+            name:    "nil models",
+            llm:     nil,
+            wantErr: ErrNoModel,
+        },
+        {
+            name:      "missing provider",
+            llm:       map[string]LLMItem{"2": {Model: "gpt-4o-mini"}},
+            errSubstr: "[project.llm.2]: provider is missing",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := Validate(&Config{Project: ProjectConfig{LLM: tt.llm}})
+            if tt.wantErr == nil && tt.errSubstr == "" {
+                if err != nil {
+                    t.Errorf("Validate returned an unexpected error: %v", err)
+                }
+                return
+            }
+            if err == nil {
+                t.Fatal("Validate returned no error")
+            }
+            if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+                t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+            }
+            if tt.errSubstr != "" && !strings.Contains(err.Error(), tt.errSubstr) {
+                t.Errorf("Validate() = %v, want it to contain %q", err, tt.errSubstr)
+            }
+        })
+    }
+}
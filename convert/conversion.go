@@ -1,14 +1,40 @@
 package convert
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
-
+	"sync"
 )
 
+// ProgressFunc is called after each file has been processed, with the number of files done so far,
+// the total number of files to convert, the file path and the conversion error, if any. Files skipped
+// because their text is up to date are reported with ErrSkipped.
+type ProgressFunc func(done, total int, file string, err error)
+
+// ErrSkipped is reported to the progress callback for the files skipped with Options.SkipExisting.
+var ErrSkipped = errors.New("skipped, converted text is up to date")
+
+// Options defines optional settings of the conversion, such as post-processing steps applied to the
+// extracted text before it is written and progress reporting.
+type Options struct {
+	StripReferences bool         // truncate a trailing References/Bibliography/Works Cited section
+	ReflowText      bool         // join hard wrapped lines within paragraphs and collapse multiple blank lines
+	Progress        ProgressFunc // called after each file, nil disables progress reporting
+	PptxNotes       bool         // append the speaker notes to the text of each PPTX slide
+	MaxPages        int          // convert only the first MaxPages pages of each PDF, 0 converts all pages
+	PageMarkers     bool         // precede the text of each PDF page with a "--- Page N ---" line
+	ExtractTables   bool         // emit the tables detected in PDF pages as tab separated blocks
+	ExtractLinks    bool         // append the URIs of the PDF link annotations in a final "Links:" section
+	Workers         int          // number of files converted concurrently, 0 uses runtime.NumCPU()
+	SkipExisting    bool         // skip files whose .txt exists and is newer than the source file
+	OutputDir       string       // directory receiving the .txt files, created if needed, "" writes them next to the sources
+}
+
 // Convert processes files from the input directory specified in the configuration and converts them into plain text files.
 //
 // It reads the configuration settings to identify supported formats and input directory paths. The function attempts to
@@ -26,6 +52,20 @@ import (
 //   >     log.Fatalf("Conversion failed: %v", err)
 //   > }
 func Convert(inputDir, selectedFormats string) error {
+	return ConvertWithOptions(inputDir, selectedFormats, Options{})
+}
+
+// ConvertWithOptions behaves like Convert but applies the settings in options, e.g. the post-processing
+// steps enabled for the text extracted from each file and the progress callback.
+//
+// Parameters:
+//   - inputDir: The directory containing the files to convert.
+//   - selectedFormats: A comma separated list of formats to convert, e.g. "pdf,docx".
+//   - options: The Options controlling the conversion.
+//
+// Returns:
+//   - An error if any issue occurs during reading, processing, or writing the files.
+func ConvertWithOptions(inputDir, selectedFormats string, options Options) error {
 	// Load files from the input directory
 	files, err := os.ReadDir(inputDir)
 	if err != nil {
@@ -34,49 +74,183 @@ func Convert(inputDir, selectedFormats string) error {
 	}
 	// formats
 	formats := strings.Split(selectedFormats, ",")
-	// parse files
+	// collect the files to convert with their format
+	var jobs []conversionJob
 	for format := range formats {
 		for _, file := range files {
-			fullPath := filepath.Join(inputDir, file.Name())
-
 			if filepath.Ext(file.Name()) == "."+formats[format] {
-				txt_content, err := readText(fullPath, formats[format])
-				if err == nil {
-					fileNameWithoutExt := strings.TrimSuffix(file.Name(), "."+formats[format])
-					txtPath := filepath.Join(inputDir, fileNameWithoutExt+".txt")
-					
-					err = writeText(txt_content, txtPath)
-					if err != nil {
-						log.Println("Error: ", err)
-						return fmt.Errorf("error writing to file: %v", err)
-					}
-				}
-			} else if filepath.Ext(file.Name()) == ".htm" { // this is to treat the special case of html files svaed with .htm extension
-				txt_content, err := readText(fullPath, "html")
-				if err == nil {
-					fileNameWithoutExt := strings.TrimSuffix(file.Name(), ".htm")
-					txtPath := filepath.Join(inputDir, fileNameWithoutExt+".txt")
-					err = writeText(txt_content, txtPath)
-					if err != nil {
-						log.Println("Error: ", err)
-						return fmt.Errorf("error writing to file: %v", err)
-					}
-				}
+				jobs = append(jobs, conversionJob{file.Name(), formats[format], "."+formats[format]})
 			}
 		}
 	}
-	return nil
+	for _, file := range files {
+		if filepath.Ext(file.Name()) == ".htm" { // this is to treat the special case of html files svaed with .htm extension
+			jobs = append(jobs, conversionJob{file.Name(), "html", ".htm"})
+		}
+	}
+	// output directory
+	outputDir := inputDir
+	if options.OutputDir != "" {
+		if err := os.MkdirAll(options.OutputDir, 0755); err != nil {
+			log.Println("Error: ", err)
+			return fmt.Errorf("error creating output directory: %v", err)
+		}
+		outputDir = options.OutputDir
+	}
+	// parse files
+	return convertJobs(inputDir, outputDir, jobs, options)
+}
+
+// convertJobs converts the jobs through a pool of options.Workers goroutines, each reading its file with
+// a fresh reader and writing its own .txt file to outputDir. Progress is reported from the calling goroutine in job
+// order, so callbacks see the same sequence as a serial conversion. A write error stops the dispatch of
+// the remaining jobs and is returned once the running ones are done and reported.
+func convertJobs(inputDir, outputDir string, jobs []conversionJob, options Options) error {
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	indexes := make(chan int)
+	results := make(chan conversionResult, len(jobs))
+	stop := make(chan struct{})
+
+	go func() {
+		defer close(indexes)
+		for i := range jobs {
+			select {
+			case indexes <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results <- convertJob(inputDir, outputDir, jobs[i], i, options)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var writeErr error
+	pending := make(map[int]conversionResult)
+	next := 0
+	for result := range results {
+		pending[result.index] = result
+		// jobs are dispatched in order, so the results of the jobs already running fill the gaps
+		for {
+			current, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			reportProgress(options, next, len(jobs), current.path, current.err)
+			if current.writeErr && writeErr == nil {
+				writeErr = fmt.Errorf("error writing to file: %v", current.err)
+				close(stop)
+			}
+		}
+	}
+	return writeErr
+}
+
+// conversionResult is the outcome of a conversionJob; writeErr marks errors writing the .txt file,
+// which abort the conversion, as opposed to errors reading the source file, which are only reported
+type conversionResult struct {
+	index    int
+	path     string
+	err      error
+	writeErr bool
+}
+
+// convertJob reads, post-processes and writes the text of a single file to outputDir
+func convertJob(inputDir, outputDir string, job conversionJob, index int, options Options) conversionResult {
+	fullPath := filepath.Join(inputDir, job.name)
+	fileNameWithoutExt := strings.TrimSuffix(job.name, job.ext)
+	txtPath := filepath.Join(outputDir, fileNameWithoutExt+".txt")
+	if options.SkipExisting && isUpToDate(txtPath, fullPath) {
+		log.Printf("Skipping %s, %s is up to date\n", fullPath, txtPath)
+		return conversionResult{index: index, path: fullPath, err: ErrSkipped}
+	}
+
+	txt_content, err := readText(fullPath, job.format, options)
+	if err != nil {
+		return conversionResult{index: index, path: fullPath, err: err}
+	}
+	txt_content = postProcess(txt_content, options)
+
+	err = writeText(txt_content, txtPath)
+	if err != nil {
+		log.Println("Error: ", err)
+		return conversionResult{index: index, path: fullPath, err: err, writeErr: true}
+	}
+	return conversionResult{index: index, path: fullPath}
+}
+
+// conversionJob is a file to convert, with the format used to read it and the extension to replace with .txt
+type conversionJob struct {
+	name   string
+	format string
+	ext    string
+}
+
+// isUpToDate reports whether the converted text exists and was modified after the source file
+func isUpToDate(txtPath, sourcePath string) bool {
+	txtInfo, err := os.Stat(txtPath)
+	if err != nil {
+		return false
+	}
+	sourceInfo, err := os.Stat(sourcePath)
+	if err != nil {
+		return false
+	}
+	return txtInfo.ModTime().After(sourceInfo.ModTime())
+}
+
+// reportProgress calls the progress callback, if any
+func reportProgress(options Options, done, total int, file string, err error) {
+	if options.Progress != nil {
+		options.Progress(done, total, file, err)
+	}
+}
+
+// postProcess applies the text transformations enabled in options
+func postProcess(text string, options Options) string {
+	if options.StripReferences {
+		text = stripReferences(text)
+	}
+	if options.ReflowText {
+		text = reflowText(text)
+	}
+	return text
 }
 
-func readText(file string, format string) (string, error) {
+func readText(file string, format string, options Options) (string, error) {
 	var modelFunc func(string) (string, error)
 	switch format {
 	case "pdf":
-		modelFunc = readPdf
+		modelFunc = func(path string) (string, error) { return readPdf(path, options) }
 	case "docx":
 		modelFunc = readDocx
 	case "html":
 		modelFunc = readHtml
+	case "pptx":
+		modelFunc = func(path string) (string, error) { return readPptx(path, options.PptxNotes) }
+	case "rtf":
+		modelFunc = readRtf
 	default:
 		log.Println("Unsupported document type: ", format)
 		return "", fmt.Errorf("unsupported document type: %s", format)
@@ -1,10 +1,13 @@
 package convert
 
 import (
+    "errors"
+    "fmt"
     "os"
     "path/filepath"
     "strings"
     "testing"
+    "time"
 
 )
 
@@ -55,3 +58,252 @@ func TestConvertHTML(t *testing.T) {
 
     // Step 6: Clean-up is handled by defer os.RemoveAll(tempDir)
 }
+
+func TestConvertProgress(t *testing.T) {
+    tempDir := t.TempDir()
+
+    // One valid HTML file and one DOCX file that cannot be parsed
+    err := os.WriteFile(filepath.Join(tempDir, "valid.html"), []byte("<html><body><p>Valid</p></body></html>"), 0644)
+    if err != nil {
+        t.Fatalf("Failed to write test HTML file: %v", err)
+    }
+    err = os.WriteFile(filepath.Join(tempDir, "broken.docx"), []byte("not a docx archive"), 0644)
+    if err != nil {
+        t.Fatalf("Failed to write test DOCX file: %v", err)
+    }
+
+    type call struct {
+        done, total int
+        file        string
+        failed      bool
+    }
+    var calls []call
+    options := Options{
+        Progress: func(done, total int, file string, err error) {
+            calls = append(calls, call{done, total, filepath.Base(file), err != nil})
+        },
+    }
+
+    err = ConvertWithOptions(tempDir, "html,docx", options)
+    if err != nil {
+        t.Fatalf("ConvertWithOptions returned an error: %v", err)
+    }
+
+    expected := []call{
+        {1, 2, "valid.html", false},
+        {2, 2, "broken.docx", true},
+    }
+    if len(calls) != len(expected) {
+        t.Fatalf("Expected %d progress calls, got %d: %v", len(expected), len(calls), calls)
+    }
+    for i := range expected {
+        if calls[i] != expected[i] {
+            t.Errorf("Progress call %d = %+v, want %+v", i, calls[i], expected[i])
+        }
+    }
+}
+
+func TestConvertHTMLWindows1252(t *testing.T) {
+    tempDir := t.TempDir()
+
+    // "Café résumé naïve" encoded in windows-1252, with the curly quotes only defined in that code page
+    htmlContent := []byte("<html><head><meta http-equiv=\"Content-Type\" content=\"text/html; charset=windows-1252\"></head>" +
+        "<body><p>Caf\xe9 r\xe9sum\xe9 na\xefve \x93quoted\x94</p></body></html>")
+    err := os.WriteFile(filepath.Join(tempDir, "legacy.html"), htmlContent, 0644)
+    if err != nil {
+        t.Fatalf("Failed to write test HTML file: %v", err)
+    }
+
+    err = Convert(tempDir, "html")
+    if err != nil {
+        t.Fatalf("Convert returned an error: %v", err)
+    }
+
+    content, err := os.ReadFile(filepath.Join(tempDir, "legacy.txt"))
+    if err != nil {
+        t.Fatalf("Expected output file does not exist: %v", err)
+    }
+    expectedText := "Café résumé naïve “quoted”"
+    if !strings.Contains(string(content), expectedText) {
+        t.Errorf("Converted text does not contain expected content.\nExpected to find: %s\nActual content: %s", expectedText, string(content))
+    }
+}
+
+func TestConvertWorkersMatchSerial(t *testing.T) {
+    // writeSources fills a directory with the same mix of HTML, RTF and PDF files
+    writeSources := func(dir string) {
+        for i := 0; i < 8; i++ {
+            files := map[string][]byte{
+                fmt.Sprintf("page%d.html", i): []byte(fmt.Sprintf("<html><body><p>HTML document %d</p></body></html>", i)),
+                fmt.Sprintf("note%d.rtf", i):  []byte(fmt.Sprintf(`{\rtf1\ansi RTF document %d\par}`, i)),
+                fmt.Sprintf("paper%d.pdf", i): buildTestPdf([]string{fmt.Sprintf("PDF document %d", i), "Second page"}),
+            }
+            for name, content := range files {
+                if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+                    t.Fatalf("Failed to write test file %s: %v", name, err)
+                }
+            }
+        }
+    }
+    // convertDir converts a directory and returns the converted texts by file name
+    convertDir := func(dir string, workers int) map[string]string {
+        var done []int
+        options := Options{
+            Workers: workers,
+            Progress: func(d, total int, file string, err error) {
+                if err != nil {
+                    t.Errorf("Conversion of %s failed: %v", file, err)
+                }
+                done = append(done, d)
+            },
+        }
+        if err := ConvertWithOptions(dir, "html,rtf,pdf", options); err != nil {
+            t.Fatalf("ConvertWithOptions with %d workers returned an error: %v", workers, err)
+        }
+        for i, d := range done {
+            if d != i+1 {
+                t.Errorf("Progress with %d workers reported %v, want a count increasing by one", workers, done)
+                break
+            }
+        }
+        texts := make(map[string]string)
+        matches, _ := filepath.Glob(filepath.Join(dir, "*.txt"))
+        for _, match := range matches {
+            content, err := os.ReadFile(match)
+            if err != nil {
+                t.Fatalf("Failed to read converted file %s: %v", match, err)
+            }
+            texts[filepath.Base(match)] = string(content)
+        }
+        return texts
+    }
+
+    serialDir, parallelDir := t.TempDir(), t.TempDir()
+    writeSources(serialDir)
+    writeSources(parallelDir)
+
+    serial := convertDir(serialDir, 1)
+    parallel := convertDir(parallelDir, 4)
+
+    if len(serial) != 24 {
+        t.Fatalf("Expected 24 converted files, got %d", len(serial))
+    }
+    if len(parallel) != len(serial) {
+        t.Fatalf("Parallel conversion produced %d files, serial conversion %d", len(parallel), len(serial))
+    }
+    for name, text := range serial {
+        if parallel[name] != text {
+            t.Errorf("Converted text of %s differs:\nserial: %q\nparallel: %q", name, text, parallel[name])
+        }
+    }
+}
+
+func TestConvertWriteErrorReportsRunningJobs(t *testing.T) {
+    tempDir := t.TempDir()
+    for i := 0; i < 8; i++ {
+        content := []byte(fmt.Sprintf("<html><body><p>HTML document %d</p></body></html>", i))
+        if err := os.WriteFile(filepath.Join(tempDir, fmt.Sprintf("doc%d.html", i)), content, 0644); err != nil {
+            t.Fatalf("Failed to write test HTML file: %v", err)
+        }
+    }
+    // a directory in place of doc3.txt makes writing its text fail
+    if err := os.Mkdir(filepath.Join(tempDir, "doc3.txt"), 0755); err != nil {
+        t.Fatalf("Failed to create directory: %v", err)
+    }
+
+    var reported []string
+    options := Options{
+        Workers: 4,
+        Progress: func(done, total int, file string, err error) {
+            if done != len(reported)+1 {
+                t.Errorf("Progress reported %d after %d calls, want a count increasing by one", done, len(reported))
+            }
+            reported = append(reported, filepath.Base(file))
+        },
+    }
+    if err := ConvertWithOptions(tempDir, "html", options); err == nil {
+        t.Fatal("Expected a write error")
+    }
+
+    if len(reported) < 4 || reported[3] != "doc3.html" {
+        t.Fatalf("Expected the failing doc3.html to be reported fourth, got %v", reported)
+    }
+    // every job dispatched before the error, i.e. every file written, is reported
+    matches, _ := filepath.Glob(filepath.Join(tempDir, "*.txt"))
+    if len(matches) != len(reported) {
+        t.Errorf("Expected %d progress calls for the %d jobs run, got %v", len(matches), len(matches), reported)
+    }
+}
+
+func TestConvertSkipExisting(t *testing.T) {
+    tempDir := t.TempDir()
+    htmlPath := filepath.Join(tempDir, "paper.html")
+    txtPath := filepath.Join(tempDir, "paper.txt")
+    if err := os.WriteFile(htmlPath, []byte("<html><body><p>Fresh text</p></body></html>"), 0644); err != nil {
+        t.Fatalf("Failed to write test HTML file: %v", err)
+    }
+    // an existing conversion, newer than the source file
+    if err := os.WriteFile(txtPath, []byte("Previous conversion"), 0644); err != nil {
+        t.Fatalf("Failed to write test text file: %v", err)
+    }
+    now := time.Now()
+    if err := os.Chtimes(htmlPath, now.Add(-time.Hour), now.Add(-time.Hour)); err != nil {
+        t.Fatalf("Failed to set source modification time: %v", err)
+    }
+
+    var reported error
+    options := Options{
+        SkipExisting: true,
+        Progress: func(done, total int, file string, err error) {
+            reported = err
+        },
+    }
+    if err := ConvertWithOptions(tempDir, "html", options); err != nil {
+        t.Fatalf("ConvertWithOptions returned an error: %v", err)
+    }
+    if !errors.Is(reported, ErrSkipped) {
+        t.Errorf("Expected the up to date file to be reported as skipped, got %v", reported)
+    }
+    if content, _ := os.ReadFile(txtPath); string(content) != "Previous conversion" {
+        t.Errorf("Up to date text was overwritten: %q", content)
+    }
+
+    // a source file modified after the conversion is converted again
+    if err := os.Chtimes(htmlPath, now.Add(time.Hour), now.Add(time.Hour)); err != nil {
+        t.Fatalf("Failed to set source modification time: %v", err)
+    }
+    if err := ConvertWithOptions(tempDir, "html", options); err != nil {
+        t.Fatalf("ConvertWithOptions returned an error: %v", err)
+    }
+    if reported != nil {
+        t.Errorf("Expected the modified file to be converted, got %v", reported)
+    }
+    if content, _ := os.ReadFile(txtPath); !strings.Contains(string(content), "Fresh text") {
+        t.Errorf("Modified source was not converted again: %q", content)
+    }
+}
+
+func TestConvertOutputDir(t *testing.T) {
+    inputDir := t.TempDir()
+    outputDir := filepath.Join(t.TempDir(), "texts", "converted")
+    err := os.WriteFile(filepath.Join(inputDir, "paper.html"), []byte("<html><body><p>Separate output</p></body></html>"), 0644)
+    if err != nil {
+        t.Fatalf("Failed to write test HTML file: %v", err)
+    }
+
+    err = ConvertWithOptions(inputDir, "html", Options{OutputDir: outputDir})
+    if err != nil {
+        t.Fatalf("ConvertWithOptions returned an error: %v", err)
+    }
+
+    content, err := os.ReadFile(filepath.Join(outputDir, "paper.txt"))
+    if err != nil {
+        t.Fatalf("Expected output file in the output directory: %v", err)
+    }
+    if !strings.Contains(string(content), "Separate output") {
+        t.Errorf("Converted text does not contain expected content: %q", content)
+    }
+    if _, err := os.Stat(filepath.Join(inputDir, "paper.txt")); !os.IsNotExist(err) {
+        t.Errorf("Expected no text file in the input directory, got %v", err)
+    }
+}
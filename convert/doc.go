@@ -1,18 +1,26 @@
-// Package convert provides utilities to convert various document formats (PDF, DOCX, HTML) into plain text format.
+// Package convert provides utilities to convert various document formats (PDF, DOCX, HTML, PPTX, RTF) into plain text format.
 // It exposes functions to process and extract textual content from these document types.
 //
 // Overview
 //
 // The `convert` package is designed to convert a variety of document formats into plain text.
 // It supports the following formats:
-//   - PDF: Extracts text from PDF files using the `github.com/ledongthuc/pdf` library.
+//   - PDF: Extracts text from PDF files using the `github.com/ledongthuc/pdf` library, optionally limited to the first pages, with page markers, with tables kept as tab separated blocks, and with the link annotations listed.
 //   - DOCX: Converts DOCX files into plain text using the `github.com/fumiama/go-docx` library.
 //   - HTML: Strips HTML tags and extracts textual content using the `jaytaylor.com/html2text` package.
+//   - PPTX: Extracts the text runs of each slide, in slide order, and optionally the speaker notes.
+//   - RTF: Strips control words and non-text destinations (font and color tables, metadata), decoding unicode escapes.
 //
 // Exported Functions
 //
 // Convert: Converts all supported document files from the input directory to plain text files based on the configuration settings.
 //
+// ConvertWithOptions: Same as Convert, applying optional post-processing steps (e.g., stripping the references section) and converting files concurrently.
+//
+// ExtractDOI: Returns the first DOI found in a converted text, preferring resolver URLs and "DOI:" labels.
+//
+// ExtractSections: Splits a converted text into title, abstract and body with best-effort heuristics.
+//
 // Example:
 //    > err := convert.Convert(config)
 //    > if err != nil {
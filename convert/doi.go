@@ -0,0 +1,56 @@
+package convert
+
+import (
+	"regexp"
+	"strings"
+)
+
+// doiPrefixed matches DOIs introduced by a resolver URL or a "DOI" label, which are the most reliable mentions.
+var doiPrefixed = regexp.MustCompile(`(?i)(?:https?://(?:dx\.)?doi\.org/|\bdoi\s*:?\s*)(10\.\d{4,9}/[^\s"'<>]+)`)
+
+// doiBare matches any DOI-shaped string in the text.
+var doiBare = regexp.MustCompile(`\b(10\.\d{4,9}/[^\s"'<>]+)`)
+
+// ExtractDOI returns the first DOI found in the given text, or an empty string if none is found.
+// DOIs introduced by "https://doi.org/", "dx.doi.org" or a "DOI:" label are preferred over bare
+// matches, since the latter are more likely to come from the reference list.
+//
+// Parameters:
+//   - text: The plain text extracted from a document.
+//
+// Returns:
+//   - The DOI in the form "10.<registrant>/<suffix>", without resolver prefix and trailing punctuation.
+//
+// Example:
+//   > doi := convert.ExtractDOI("Available at https://doi.org/10.1000/xyz123.")
+//   > // doi == "10.1000/xyz123"
+func ExtractDOI(text string) string {
+	if match := doiPrefixed.FindStringSubmatch(text); match != nil {
+		return cleanDOI(match[1])
+	}
+	if match := doiBare.FindStringSubmatch(text); match != nil {
+		return cleanDOI(match[1])
+	}
+	return ""
+}
+
+// cleanDOI removes trailing punctuation and unbalanced closing brackets picked up from the surrounding sentence.
+func cleanDOI(doi string) string {
+	for {
+		trimmed := strings.TrimRight(doi, ".,;:")
+		last := ""
+		if len(trimmed) > 0 {
+			last = trimmed[len(trimmed)-1:]
+		}
+		switch {
+		case last == ")" && strings.Count(trimmed, "(") < strings.Count(trimmed, ")"),
+			last == "]" && strings.Count(trimmed, "[") < strings.Count(trimmed, "]"),
+			last == "}" && strings.Count(trimmed, "{") < strings.Count(trimmed, "}"):
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+		if trimmed == doi {
+			return doi
+		}
+		doi = trimmed
+	}
+}
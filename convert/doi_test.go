@@ -0,0 +1,58 @@
+package convert
+
+import (
+    "testing"
+)
+
+func TestExtractDOI(t *testing.T) {
+    tests := []struct {
+        name     string
+        text     string
+        expected string
+    }{
+        {
+            name:     "resolver URL",
+            text:     "Available online at https://doi.org/10.1016/j.jenvman.2020.110000.",
+            expected: "10.1016/j.jenvman.2020.110000",
+        },
+        {
+            name:     "legacy resolver URL",
+            text:     "http://dx.doi.org/10.1000/xyz123",
+            expected: "10.1000/xyz123",
+        },
+        {
+            name:     "DOI label",
+            text:     "DOI: 10.1038/nature12373; received 3 May 2013",
+            expected: "10.1038/nature12373",
+        },
+        {
+            name:     "prefixed DOI preferred over earlier bare DOI",
+            text:     "Cited as 10.1111/aaaa.1 in the text.\ndoi:10.2222/bbbb.2",
+            expected: "10.2222/bbbb.2",
+        },
+        {
+            name:     "bare DOI in parentheses",
+            text:     "as shown before (10.1002/(SICI)1097-0258(19980815)17:15<1661::AID-SIM968>3.0.CO;2-2)",
+            expected: "10.1002/(SICI)1097-0258(19980815)17:15",
+        },
+        {
+            name:     "balanced parentheses are kept",
+            text:     "see 10.1016/S0140-6736(20)30183-5.",
+            expected: "10.1016/S0140-6736(20)30183-5",
+        },
+        {
+            name:     "no DOI",
+            text:     "This document has no identifier.",
+            expected: "",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := ExtractDOI(tt.text)
+            if got != tt.expected {
+                t.Errorf("ExtractDOI() = %q, want %q", got, tt.expected)
+            }
+        })
+    }
+}
@@ -1,18 +1,26 @@
 package convert
 
 import (
+	"bytes"
 	"os"
 
+	charset "golang.org/x/net/html/charset"
 	html "jaytaylor.com/html2text"
 )
 
 func readHtml(path string) (string, error) {
-	// Open the HTML file
-	file, err := os.Open(path)
+	// Read the HTML file
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	// Transcode to UTF-8 based on the byte order mark or the <meta charset> and <meta http-equiv="Content-Type">
+	// declarations, falling back to UTF-8 if the content is valid UTF-8 and to windows-1252 otherwise
+	reader, err := charset.NewReader(bytes.NewReader(content), "")
 	if err != nil {
 		return "", err
 	}
-	defer file.Close()
 
 	// Set options with TextOnly flag set to true
 	options := html.Options{
@@ -20,7 +28,7 @@ func readHtml(path string) (string, error) {
 	}
 
 	// Convert HTML to plain text
-	text, err := html.FromReader(file, options)
+	text, err := html.FromReader(reader, options)
 	if err != nil {
 		return "", err
 	}
@@ -1,9 +1,11 @@
 package convert
 
 import (
+    "fmt"
     "log"
     "os"
     "regexp"
+    "sync"
     pdf "github.com/ledongthuc/pdf"
 
 	api "github.com/pdfcpu/pdfcpu/pkg/api"
@@ -11,8 +13,12 @@ import (
     pdfTypes "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
-// Primary text extraction function using github.com/ledongthuc/pdf
-func readPdf(path string) (string, error) {
+// Primary text extraction function using github.com/ledongthuc/pdf.
+// Only the first options.MaxPages pages are extracted when MaxPages is positive, and each page
+// is preceded by a page marker when options.PageMarkers is set. With options.ExtractTables, the
+// text is rebuilt from the glyph positions to preserve tables as tab separated blocks. With
+// options.ExtractLinks, the URIs of the link annotations are appended in a final "Links:" section.
+func readPdf(path string, options Options) (string, error) {
     text := ""
 
     // Open the PDF file
@@ -29,12 +35,30 @@ func readPdf(path string) (string, error) {
         return "", nil
     }
 
-    for pageIndex := 1; pageIndex <= totalPage; pageIndex++ {
+    var links []string
+    lastPage := pagesToExtract(totalPage, options.MaxPages)
+    for pageIndex := 1; pageIndex <= lastPage; pageIndex++ {
+        if options.PageMarkers {
+            text += pageMarker(pageIndex)
+        }
         p := r.Page(pageIndex)
         if p.V.IsNull() {
             log.Printf("Page %d is null or not available", pageIndex)
             continue
         }
+        if options.ExtractLinks {
+            links = append(links, pageLinks(p)...)
+        }
+
+        if options.ExtractTables {
+            pageText, err := pageTextWithTables(p)
+            if err != nil {
+                log.Printf("Error retrieving text for page %d: %v", pageIndex, err)
+                continue
+            }
+            text += pageText
+            continue
+        }
 
         rows, err := p.GetTextByRow()
         if err != nil {
@@ -60,11 +84,43 @@ func readPdf(path string) (string, error) {
     }
 
     // Fallback if no text was extracted
-    if text == "" {
+    if stripPageMarkers(text) == "" {
         log.Println("No text extracted from any pages of the PDF, attempting alternative method.")
-        return extractTextWithPdfCpu(path)
+        fallbackText, err := extractTextWithPdfCpu(path, options)
+        if err != nil {
+            return "", err
+        }
+        return fallbackText + linksSection(links), nil
+    }
+    if options.PageMarkers && lastPage < totalPage {
+        text += truncationMarker(lastPage, totalPage)
+    }
+    return text + linksSection(links), nil
+}
+
+// pagesToExtract returns the last page to extract given the page count and the MaxPages option (0 = all).
+func pagesToExtract(totalPages, maxPages int) int {
+    if maxPages > 0 && maxPages < totalPages {
+        return maxPages
     }
-    return text, nil
+    return totalPages
+}
+
+// pageMarker returns the line preceding the text of a page when page markers are enabled
+func pageMarker(page int) string {
+    return fmt.Sprintf("--- Page %d ---\n", page)
+}
+
+// truncationMarker returns the line closing the text of a PDF converted only in part because of MaxPages
+func truncationMarker(lastPage, totalPages int) string {
+    return fmt.Sprintf("--- Pages %d-%d of %d not converted ---\n", lastPage+1, totalPages, totalPages)
+}
+
+var pageMarkerRegex = regexp.MustCompile(`(?m)^--- Page \d+ ---\n`)
+
+// stripPageMarkers removes the page markers, used to detect PDFs without any extractable text
+func stripPageMarkers(text string) string {
+    return pageMarkerRegex.ReplaceAllString(text, "")
 }
 
 // Convert a []Text to a single string by concatenating the Value fields
@@ -76,8 +132,11 @@ func textsToString(texts []pdf.Text) string {
     return result
 }
 
+// pdfcpuConfigMutex guards the creation of pdfcpu configurations
+var pdfcpuConfigMutex sync.Mutex
+
 // extractTextFromPDF reads a PDF and extracts text from each page's content stream.
-func extractTextWithPdfCpu(filePath string) (string, error) {
+func extractTextWithPdfCpu(filePath string, options Options) (string, error) {
 	// Open the PDF file
 	f, err := os.Open(filePath)
 	if err != nil {
@@ -85,8 +144,11 @@ func extractTextWithPdfCpu(filePath string) (string, error) {
 	}
 	defer f.Close()
 
-    	// Create a pdfcpu configuration with relaxed validation
+    	// Create a pdfcpu configuration with relaxed validation, the default configuration is
+	// loaded lazily into package state of pdfcpu, so concurrent conversions are serialized here
+	pdfcpuConfigMutex.Lock()
 	conf := model.NewDefaultConfiguration()
+	pdfcpuConfigMutex.Unlock()
 	conf.ValidationMode = model.ValidationRelaxed
 
 	// Create a pdfcpu configuration and context
@@ -102,7 +164,11 @@ func extractTextWithPdfCpu(filePath string) (string, error) {
 
 	var extractedText string
 	// Process each page
-	for i := 1; i <= ctx.PageCount; i++ {
+	lastPage := pagesToExtract(ctx.PageCount, options.MaxPages)
+	for i := 1; i <= lastPage; i++ {
+		if options.PageMarkers {
+			extractedText += pageMarker(i)
+		}
 		pageDict, _, _, err := ctx.PageDict(i, false)
 		if err != nil {
 			log.Printf("Error extracting page %d: %v", i, err)
@@ -202,6 +268,9 @@ func extractTextWithPdfCpu(filePath string) (string, error) {
         text := parseText(content)
         extractedText += text + "\n"
 	}
+	if options.PageMarkers && lastPage < ctx.PageCount {
+		extractedText += truncationMarker(lastPage, ctx.PageCount)
+	}
 
 	return extractedText, nil
 }
@@ -0,0 +1,161 @@
+package convert
+
+import (
+    "bytes"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+// buildTestPdf returns a minimal PDF document with one page per entry of pages, each showing its text
+func buildTestPdf(pages []string) []byte {
+    contents := make([]string, len(pages))
+    for i, page := range pages {
+        contents[i] = fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", page)
+    }
+    return buildTestPdfFromContents(contents)
+}
+
+// buildTestPdfFromContents returns a minimal PDF document with one page per content stream, using a
+// monospaced font named F1 whose glyphs are 600 units wide
+func buildTestPdfFromContents(contents []string) []byte {
+    return buildTestPdfWithAnnots(contents, nil)
+}
+
+// buildTestPdfWithAnnots is buildTestPdfFromContents with the /Annots array of each page, if any
+func buildTestPdfWithAnnots(contents []string, annots []string) []byte {
+    var objects []string
+    objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+    kids := make([]string, len(contents))
+    for i := range contents {
+        kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+    }
+    objects = append(objects, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(contents)))
+    objects = append(objects, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding /FirstChar 32 /LastChar 126 /Widths [%s] >>", strings.TrimSpace(strings.Repeat("600 ", 95))))
+    for i, content := range contents {
+        pageAnnots := ""
+        if i < len(annots) && annots[i] != "" {
+            pageAnnots = fmt.Sprintf(" /Annots [%s]", annots[i])
+        }
+        objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R%s >>", 5+2*i, pageAnnots))
+        objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+    }
+
+    var buf bytes.Buffer
+    buf.WriteString("%PDF-1.4\n")
+    offsets := make([]int, len(objects))
+    for i, object := range objects {
+        offsets[i] = buf.Len()
+        fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+    }
+    xref := buf.Len()
+    fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+    for _, offset := range offsets {
+        fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+    }
+    fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+    return buf.Bytes()
+}
+
+func TestReadPdfMaxPages(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "book.pdf")
+    if err := os.WriteFile(path, buildTestPdf([]string{"First page", "Second page", "Third page"}), 0644); err != nil {
+        t.Fatalf("Failed to write test PDF file: %v", err)
+    }
+
+    tests := []struct {
+        name     string
+        options  Options
+        expected string
+    }{
+        {"all pages", Options{}, "First page\nSecond page\nThird page\n"},
+        {"max pages", Options{MaxPages: 2}, "First page\nSecond page\n"},
+        {"max pages above page count", Options{MaxPages: 5}, "First page\nSecond page\nThird page\n"},
+        {"page markers", Options{PageMarkers: true}, "--- Page 1 ---\nFirst page\n--- Page 2 ---\nSecond page\n--- Page 3 ---\nThird page\n"},
+        {"max pages with page markers", Options{MaxPages: 1, PageMarkers: true}, "--- Page 1 ---\nFirst page\n--- Pages 2-3 of 3 not converted ---\n"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            text, err := readPdf(path, tt.options)
+            if err != nil {
+                t.Fatalf("readPdf returned an error: %v", err)
+            }
+            if text != tt.expected {
+                t.Errorf("readPdf() = %q, want %q", text, tt.expected)
+            }
+        })
+    }
+}
+
+func TestReadPdfExtractTables(t *testing.T) {
+    show := func(x, y int, text string) string {
+        return fmt.Sprintf("BT /F1 10 Tf %d %d Td (%s) Tj ET\n", x, y, text)
+    }
+    content := show(72, 720, "Results of the models") +
+        show(72, 700, "Model") + show(200, 700, "Score") + show(300, 700, "Time") +
+        show(72, 686, "Linear") + show(200, 686, "0.81") + show(300, 686, "12 s") +
+        show(72, 672, "Random forest") + show(200, 672, "0.93") + show(300, 672, "85 s") +
+        show(72, 650, "The forest performs best.")
+
+    path := filepath.Join(t.TempDir(), "tables.pdf")
+    if err := os.WriteFile(path, buildTestPdfFromContents([]string{content}), 0644); err != nil {
+        t.Fatalf("Failed to write test PDF file: %v", err)
+    }
+
+    text, err := readPdf(path, Options{ExtractTables: true})
+    if err != nil {
+        t.Fatalf("readPdf returned an error: %v", err)
+    }
+    expected := "Results of the models\n" +
+        "--- Table ---\n" +
+        "Model\tScore\tTime\n" +
+        "Linear\t0.81\t12 s\n" +
+        "Random forest\t0.93\t85 s\n" +
+        "--- End of table ---\n" +
+        "The forest performs best.\n"
+    if text != expected {
+        t.Errorf("readPdf() = %q, want %q", text, expected)
+    }
+}
+
+func TestReadPdfExtractLinks(t *testing.T) {
+    link := func(uri string) string {
+        return fmt.Sprintf("<< /Type /Annot /Subtype /Link /Rect [72 700 300 720] /A << /S /URI /URI (%s) >> >>", uri)
+    }
+    contents := []string{
+        "BT /F1 12 Tf 72 720 Td (A paper with links) Tj ET",
+        "BT /F1 12 Tf 72 720 Td (Data availability) Tj ET",
+    }
+    annots := []string{
+        link("https://doi.org/10.1234/abcd") + " << /Type /Annot /Subtype /Text /Rect [0 0 10 10] /Contents (A note) >>",
+        link("https://zenodo.org/records/42") + " " + link("https://doi.org/10.1234/abcd"),
+    }
+    path := filepath.Join(t.TempDir(), "links.pdf")
+    if err := os.WriteFile(path, buildTestPdfWithAnnots(contents, annots), 0644); err != nil {
+        t.Fatalf("Failed to write test PDF file: %v", err)
+    }
+
+    text, err := readPdf(path, Options{ExtractLinks: true})
+    if err != nil {
+        t.Fatalf("readPdf returned an error: %v", err)
+    }
+    expected := "A paper with links\n" +
+        "Data availability\n" +
+        "\nLinks:\n" +
+        "https://doi.org/10.1234/abcd\n" +
+        "https://zenodo.org/records/42\n"
+    if text != expected {
+        t.Errorf("readPdf() = %q, want %q", text, expected)
+    }
+
+    text, err = readPdf(path, Options{})
+    if err != nil {
+        t.Fatalf("readPdf returned an error: %v", err)
+    }
+    if strings.Contains(text, "Links:") {
+        t.Errorf("Links extracted without ExtractLinks: %q", text)
+    }
+}
@@ -0,0 +1,57 @@
+package convert
+
+import (
+    "log"
+    "strings"
+
+    pdf "github.com/ledongthuc/pdf"
+)
+
+const linksHeading = "Links:"
+
+// pageLinks returns the URIs of the link annotations of a PDF page, e.g. the DOI of the paper or the
+// address of a dataset, in annotation order
+func pageLinks(p pdf.Page) (links []string) {
+    // the pdf library panics on malformed objects
+    defer func() {
+        if r := recover(); r != nil {
+            log.Printf("Error reading link annotations: %v", r)
+            links = nil
+        }
+    }()
+
+    annots := p.V.Key("Annots")
+    for i := 0; i < annots.Len(); i++ {
+        annot := annots.Index(i)
+        if annot.Key("Subtype").Name() != "Link" {
+            continue
+        }
+        action := annot.Key("A")
+        if action.Key("S").Name() != "URI" {
+            continue
+        }
+        if uri := strings.TrimSpace(action.Key("URI").RawString()); uri != "" {
+            links = append(links, uri)
+        }
+    }
+    return links
+}
+
+// linksSection formats the links as a final section of the text, one per line without duplicates,
+// or returns an empty string when there are no links
+func linksSection(links []string) string {
+    if len(links) == 0 {
+        return ""
+    }
+    var builder strings.Builder
+    builder.WriteString("\n" + linksHeading + "\n")
+    seen := make(map[string]bool)
+    for _, link := range links {
+        if seen[link] {
+            continue
+        }
+        seen[link] = true
+        builder.WriteString(link + "\n")
+    }
+    return builder.String()
+}
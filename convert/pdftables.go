@@ -0,0 +1,119 @@
+package convert
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+
+    pdf "github.com/ledongthuc/pdf"
+)
+
+const (
+    tableCellGap = 1.0 // minimum horizontal gap between two cells, in multiples of the font size
+    tableWordGap = 0.2 // minimum horizontal gap between two words, in multiples of the font size
+    tableMinRows = 2   // minimum number of consecutive multi-cell rows forming a table
+    tableStart   = "--- Table ---"
+    tableEnd     = "--- End of table ---"
+)
+
+// pageTextWithTables extracts the text of a PDF page from the position of its glyphs. Consecutive rows
+// split in more than one cell by wide horizontal gaps are emitted as tab separated blocks enclosed in
+// table markers, while all other rows are emitted as plain lines. The column alignment is approximate.
+func pageTextWithTables(p pdf.Page) (text string, err error) {
+    // the pdf library panics on malformed content streams
+    defer func() {
+        if r := recover(); r != nil {
+            text = ""
+            err = fmt.Errorf("error reading page content: %v", r)
+        }
+    }()
+
+    var builder strings.Builder
+    var table [][]string
+    flushTable := func() {
+        if len(table) >= tableMinRows {
+            builder.WriteString(tableStart + "\n")
+            for _, cells := range table {
+                builder.WriteString(strings.Join(cells, "\t") + "\n")
+            }
+            builder.WriteString(tableEnd + "\n")
+        } else {
+            for _, cells := range table {
+                builder.WriteString(strings.Join(cells, " ") + "\n")
+            }
+        }
+        table = nil
+    }
+
+    for _, row := range positionalRows(p.Content().Text) {
+        cells := rowCells(row)
+        if len(cells) == 0 {
+            continue
+        }
+        if len(cells) > 1 {
+            table = append(table, cells)
+            continue
+        }
+        flushTable()
+        builder.WriteString(cells[0] + "\n")
+    }
+    flushTable()
+
+    return builder.String(), nil
+}
+
+// positionalRows groups glyphs sharing the same baseline into rows, top to bottom, each sorted left to right
+func positionalRows(texts []pdf.Text) [][]pdf.Text {
+    rowsByPosition := make(map[int64][]pdf.Text)
+    for _, t := range texts {
+        position := int64(t.Y)
+        rowsByPosition[position] = append(rowsByPosition[position], t)
+    }
+
+    positions := make([]int64, 0, len(rowsByPosition))
+    for position := range rowsByPosition {
+        positions = append(positions, position)
+    }
+    sort.Slice(positions, func(i, j int) bool { return positions[i] > positions[j] })
+
+    rows := make([][]pdf.Text, len(positions))
+    for i, position := range positions {
+        row := rowsByPosition[position]
+        sort.SliceStable(row, func(a, b int) bool { return row[a].X < row[b].X })
+        rows[i] = row
+    }
+    return rows
+}
+
+// rowCells splits a row of glyphs into cells at horizontal gaps wider than tableCellGap font sizes,
+// inserting a space at narrower gaps between words
+func rowCells(row []pdf.Text) []string {
+    var cells []string
+    var cell strings.Builder
+    flushCell := func() {
+        if value := strings.TrimSpace(cell.String()); value != "" {
+            cells = append(cells, value)
+        }
+        cell.Reset()
+    }
+
+    for i, t := range row {
+        if i > 0 {
+            previous := row[i-1]
+            fontSize := previous.FontSize
+            if fontSize <= 0 {
+                fontSize = 1
+            }
+            gap := t.X - (previous.X + previous.W)
+            if gap > tableCellGap*fontSize {
+                flushCell()
+            } else if gap > tableWordGap*fontSize && !strings.HasSuffix(cell.String(), " ") {
+                cell.WriteString(" ")
+            }
+        }
+        cell.WriteString(strings.ReplaceAll(t.S, "\t", " "))
+    }
+    flushCell()
+
+    return cells
+}
@@ -0,0 +1,165 @@
+package convert
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// drawingML is the namespace of the <a:...> elements holding the text runs in slides and notes
+const drawingML = "http://schemas.openxmlformats.org/drawingml/2006/main"
+
+var slidePath = regexp.MustCompile(`^ppt/slides/slide(\d+)\.xml$`)
+
+// relationships mirrors the .rels parts of the OOXML package
+type relationships struct {
+	Relationships []struct {
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// readPptx extracts the text of each slide, in slide order, separating slides with a marker line.
+// If withNotes is true, the speaker notes of each slide are appended after the slide text.
+func readPptx(filePath string, withNotes bool) (string, error) {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+
+	parts := make(map[string]*zip.File)
+	type slide struct {
+		number int
+		name   string
+	}
+	var slides []slide
+	for _, f := range archive.File {
+		parts[f.Name] = f
+		if match := slidePath.FindStringSubmatch(f.Name); match != nil {
+			number, _ := strconv.Atoi(match[1])
+			slides = append(slides, slide{number, f.Name})
+		}
+	}
+	sort.Slice(slides, func(i, j int) bool { return slides[i].number < slides[j].number })
+
+	var textBuilder strings.Builder
+	for _, s := range slides {
+		text, err := readSlideText(parts[s.name])
+		if err != nil {
+			return "", fmt.Errorf("error reading slide %d: %v", s.number, err)
+		}
+		textBuilder.WriteString(fmt.Sprintf("--- Slide %d ---\n", s.number))
+		textBuilder.WriteString(text)
+
+		if withNotes {
+			notesName := findNotesPart(parts, s.name)
+			if notesName != "" {
+				notes, err := readSlideText(parts[notesName])
+				if err != nil {
+					return "", fmt.Errorf("error reading notes of slide %d: %v", s.number, err)
+				}
+				if strings.TrimSpace(notes) != "" {
+					textBuilder.WriteString("Notes:\n")
+					textBuilder.WriteString(notes)
+				}
+			}
+		}
+		textBuilder.WriteString("\n")
+	}
+	return textBuilder.String(), nil
+}
+
+// findNotesPart returns the name of the notes part linked to the slide, or an empty string if the slide has no notes
+func findNotesPart(parts map[string]*zip.File, slideName string) string {
+	relsName := path.Join(path.Dir(slideName), "_rels", path.Base(slideName)+".rels")
+	relsFile, ok := parts[relsName]
+	if !ok {
+		return ""
+	}
+	reader, err := relsFile.Open()
+	if err != nil {
+		return ""
+	}
+	defer reader.Close()
+
+	var rels relationships
+	if err := xml.NewDecoder(reader).Decode(&rels); err != nil {
+		return ""
+	}
+	for _, rel := range rels.Relationships {
+		if strings.HasSuffix(rel.Type, "/notesSlide") {
+			notesName := path.Join(path.Dir(slideName), rel.Target)
+			if _, ok := parts[notesName]; ok {
+				return notesName
+			}
+		}
+	}
+	return ""
+}
+
+// readSlideText collects the <a:t> text runs of a slide or notes part, one line per <a:p> paragraph.
+// Text in <a:fld> fields (e.g., slide numbers) is skipped.
+func readSlideText(f *zip.File) (string, error) {
+	reader, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	var textBuilder strings.Builder
+	var line strings.Builder
+	decoder := xml.NewDecoder(reader)
+	inText := false
+	inField := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Space != drawingML {
+				continue
+			}
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "fld":
+				inField++
+			case "br":
+				line.WriteString("\n")
+			}
+		case xml.EndElement:
+			if t.Name.Space != drawingML {
+				continue
+			}
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "fld":
+				inField--
+			case "p":
+				if strings.TrimSpace(line.String()) != "" {
+					textBuilder.WriteString(line.String())
+					textBuilder.WriteString("\n")
+				}
+				line.Reset()
+			}
+		case xml.CharData:
+			if inText && inField == 0 {
+				line.Write(t)
+			}
+		}
+	}
+	return textBuilder.String(), nil
+}
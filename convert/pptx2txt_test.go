@@ -0,0 +1,95 @@
+package convert
+
+import (
+    "archive/zip"
+    "fmt"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+const testSlideTemplate = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">
+<p:cSld><p:spTree><p:sp><p:txBody>%s</p:txBody></p:sp></p:spTree></p:cSld>
+</p:sld>`
+
+// writeTestPptx creates a minimal PPTX package with the given parts
+func writeTestPptx(t *testing.T, parts map[string]string) string {
+    pptxPath := filepath.Join(t.TempDir(), "slides.pptx")
+    f, err := os.Create(pptxPath)
+    if err != nil {
+        t.Fatalf("Failed to create test PPTX file: %v", err)
+    }
+    defer f.Close()
+    w := zip.NewWriter(f)
+    for name, content := range parts {
+        part, err := w.Create(name)
+        if err != nil {
+            t.Fatalf("Failed to create part %s: %v", name, err)
+        }
+        if _, err := part.Write([]byte(content)); err != nil {
+            t.Fatalf("Failed to write part %s: %v", name, err)
+        }
+    }
+    if err := w.Close(); err != nil {
+        t.Fatalf("Failed to close test PPTX file: %v", err)
+    }
+    return pptxPath
+}
+
+func TestReadPptx(t *testing.T) {
+    slide := func(body string) string {
+        return fmt.Sprintf(testSlideTemplate, body)
+    }
+    parts := map[string]string{
+        // slide 10 is listed first to check the numeric ordering of slides
+        "ppt/slides/slide10.xml": slide(`<a:p><a:r><a:t>Last slide</a:t></a:r></a:p>`),
+        "ppt/slides/slide1.xml":  slide(`<a:p><a:r><a:t>Interest </a:t></a:r><a:r><a:t>rates</a:t></a:r></a:p><a:p><a:r><a:t>Second line</a:t></a:r></a:p>`),
+        "ppt/slides/slide2.xml":  slide(`<a:p><a:r><a:t>Regression models</a:t></a:r><a:fld type="slidenum"><a:t>2</a:t></a:fld></a:p>`),
+        "ppt/slides/_rels/slide2.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/>
+</Relationships>`,
+        "ppt/notesSlides/notesSlide1.xml": slide(`<a:p><a:r><a:t>Speaker notes</a:t></a:r></a:p>`),
+    }
+    pptxPath := writeTestPptx(t, parts)
+
+    tests := []struct {
+        name      string
+        withNotes bool
+        expected  string
+    }{
+        {
+            name:      "slides only",
+            withNotes: false,
+            expected:  "--- Slide 1 ---\nInterest rates\nSecond line\n\n--- Slide 2 ---\nRegression models\n\n--- Slide 10 ---\nLast slide\n\n",
+        },
+        {
+            name:      "slides with notes",
+            withNotes: true,
+            expected:  "--- Slide 1 ---\nInterest rates\nSecond line\n\n--- Slide 2 ---\nRegression models\nNotes:\nSpeaker notes\n\n--- Slide 10 ---\nLast slide\n\n",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := readPptx(pptxPath, tt.withNotes)
+            if err != nil {
+                t.Fatalf("readPptx returned an error: %v", err)
+            }
+            if got != tt.expected {
+                t.Errorf("readPptx() = %q, want %q", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestReadPptxInvalidFile(t *testing.T) {
+    invalidPath := filepath.Join(t.TempDir(), "invalid.pptx")
+    if err := os.WriteFile(invalidPath, []byte("not a zip archive"), 0644); err != nil {
+        t.Fatalf("Failed to write test file: %v", err)
+    }
+    if _, err := readPptx(invalidPath, false); err == nil {
+        t.Error("Expected an error for an invalid PPTX file, got nil")
+    }
+}
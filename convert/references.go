@@ -0,0 +1,30 @@
+package convert
+
+import (
+	"log"
+	"regexp"
+	"strings"
+)
+
+// referencesMinPosition is the fraction of the document after which a references heading
+// is considered to start the trailing bibliography rather than being a mid-article mention.
+const referencesMinPosition = 0.6
+
+// referencesHeading matches a line consisting only of a references heading, optionally numbered
+// (e.g., "7. References") and followed by a colon.
+var referencesHeading = regexp.MustCompile(`(?im)^[ \t]*(?:\d+\.?[ \t]*)?(?:references|bibliography|works cited)[ \t]*:?[ \t]*$`)
+
+// stripReferences removes the trailing references section from the text. Only the last heading found
+// is considered and only when it appears in the last part of the document, otherwise the text is returned unchanged.
+func stripReferences(text string) string {
+	matches := referencesHeading.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+	start := matches[len(matches)-1][0]
+	if float64(start) < float64(len(text))*referencesMinPosition {
+		log.Println("References heading found too early in the document, text left unchanged")
+		return text
+	}
+	return strings.TrimRight(text[:start], " \t\r\n") + "\n"
+}
@@ -0,0 +1,56 @@
+package convert
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestStripReferences(t *testing.T) {
+    body := strings.Repeat("This paragraph discusses interest rates and regression models.\n", 20)
+
+    tests := []struct {
+        name     string
+        text     string
+        expected string
+    }{
+        {
+            name:     "trailing references section is removed",
+            text:     body + "References\nDoe, J. (2020). A study. Journal.\n",
+            expected: body,
+        },
+        {
+            name:     "numbered heading with colon",
+            text:     body + "7. Bibliography:\nDoe, J. (2020). A study. Journal.\n",
+            expected: body,
+        },
+        {
+            name:     "works cited in upper case",
+            text:     body + "WORKS CITED\nDoe, J. (2020). A study. Journal.\n",
+            expected: body,
+        },
+        {
+            name:     "no references section",
+            text:     body,
+            expected: body,
+        },
+        {
+            name:     "heading too early in the document is kept",
+            text:     "References\n" + body,
+            expected: "References\n" + body,
+        },
+        {
+            name:     "mention inside a sentence is kept",
+            text:     body + "See the references for details.\n",
+            expected: body + "See the references for details.\n",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := stripReferences(tt.text)
+            if got != tt.expected {
+                t.Errorf("stripReferences() = %q, want %q", got, tt.expected)
+            }
+        })
+    }
+}
@@ -0,0 +1,102 @@
+package convert
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// blankLines matches the blank lines separating paragraphs
+var blankLines = regexp.MustCompile(`\n(?:[ \t]*\n)+`)
+
+// horizontalSpace matches runs of spaces and tabs within a line
+var horizontalSpace = regexp.MustCompile(`[ \t]+`)
+
+// structuralLine matches the marker lines added by the conversion, e.g. "--- Page 2 ---" or "--- Table ---"
+var structuralLine = regexp.MustCompile(`^--- .+ ---$`)
+
+// reflowText joins the lines hard wrapped within paragraphs into single lines, removing the hyphens of words
+// split across lines (e.g. "exam-\nple" becomes "example"), and collapses multiple blank lines into one,
+// keeping paragraph boundaries. Marker lines, tables and the links section are left line by line.
+func reflowText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var paragraphs []string
+	for _, block := range blankLines.Split(text, -1) {
+		if paragraph := reflowParagraph(block); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	if len(paragraphs) == 0 {
+		return ""
+	}
+	return strings.Join(paragraphs, "\n\n") + "\n"
+}
+
+// reflowParagraph joins the lines of a paragraph, keeping structural lines and the rows of tables and
+// of the links section on their own lines
+func reflowParagraph(block string) string {
+	var lines []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			lines = append(lines, current.String())
+			current.Reset()
+		}
+	}
+
+	verbatim := false // inside a table or the links section
+	for _, line := range strings.Split(block, "\n") {
+		line = strings.TrimRight(line, " \t")
+		switch {
+		case line == tableStart || line == linksHeading:
+			flush()
+			lines = append(lines, line)
+			verbatim = true
+		case line == tableEnd:
+			lines = append(lines, line)
+			verbatim = false
+		case verbatim:
+			if line != "" {
+				lines = append(lines, line)
+			}
+		case structuralLine.MatchString(line):
+			flush()
+			lines = append(lines, line)
+		default:
+			line = horizontalSpace.ReplaceAllString(strings.TrimSpace(line), " ")
+			if line == "" {
+				continue
+			}
+			joinLine(&current, line)
+		}
+	}
+	flush()
+
+	return strings.Join(lines, "\n")
+}
+
+// joinLine appends line to the paragraph being built, dropping the hyphen of a word split across the
+// line break, keeping it without a space before a capital or a digit, and separating the lines with a space otherwise
+func joinLine(current *strings.Builder, line string) {
+	if current.Len() == 0 {
+		current.WriteString(line)
+		return
+	}
+	previous := current.String()
+	next, _ := utf8.DecodeRuneInString(line)
+	if strings.HasSuffix(previous, "-") {
+		beforeHyphen, _ := utf8.DecodeLastRuneInString(strings.TrimSuffix(previous, "-"))
+		if unicode.IsLetter(beforeHyphen) && unicode.IsLower(next) {
+			current.Reset()
+			current.WriteString(strings.TrimSuffix(previous, "-") + line)
+			return
+		}
+		if unicode.IsLetter(beforeHyphen) && (unicode.IsUpper(next) || unicode.IsDigit(next)) {
+			// compound words such as COVID-19 or Franco-German keep their hyphen
+			current.WriteString(line)
+			return
+		}
+	}
+	current.WriteString(" " + line)
+}
@@ -0,0 +1,74 @@
+package convert
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestReflowText(t *testing.T) {
+    tests := []struct {
+        name     string
+        text     string
+        expected string
+    }{
+        {
+            name: "hard wrapped paragraphs",
+            text: "Interest rates were modelled with a re-\ngression on the panel of\ncountries.\n\n\n\nThe second   paragraph\nends here.\n",
+            expected: "Interest rates were modelled with a regression on the panel of countries.\n\n" +
+                "The second paragraph ends here.\n",
+        },
+        {
+            name:     "hyphen before a capital or a number is kept",
+            text:     "The COVID-\n19 pandemic and the Franco-\nGerman border.\n",
+            expected: "The COVID-19 pandemic and the Franco-German border.\n",
+        },
+        {
+            name:     "windows line endings and blank lines with spaces",
+            text:     "First line\r\nsecond line\r\n  \r\n\t\r\nNext paragraph\r\n",
+            expected: "First line second line\n\nNext paragraph\n",
+        },
+        {
+            name: "page markers and tables are kept on their own lines",
+            text: "--- Page 1 ---\nResults of\nthe models\n--- Table ---\nModel\tScore\nLinear\t0.81\n--- End of table ---\nThe forest\nperforms best.\n--- Page 2 ---\nDiscussion\n",
+            expected: "--- Page 1 ---\nResults of the models\n--- Table ---\nModel\tScore\nLinear\t0.81\n--- End of table ---\n" +
+                "The forest performs best.\n--- Page 2 ---\nDiscussion\n",
+        },
+        {
+            name:     "links section",
+            text:     "Body text\nwrapped.\n\nLinks:\nhttps://doi.org/10.1234/abcd\nhttps://zenodo.org/records/42\n",
+            expected: "Body text wrapped.\n\nLinks:\nhttps://doi.org/10.1234/abcd\nhttps://zenodo.org/records/42\n",
+        },
+        {
+            name:     "empty text",
+            text:     " \n\n\t\n",
+            expected: "",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := reflowText(tt.text)
+            if got != tt.expected {
+                t.Errorf("reflowText() = %q, want %q", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestReflowTextFixture(t *testing.T) {
+    // raw text as extracted from a PDF with page markers and tables, ending with a references section
+    raw, err := os.ReadFile(filepath.Join("testdata", "reflow_raw.txt"))
+    if err != nil {
+        t.Fatalf("Failed to read raw fixture: %v", err)
+    }
+    expected, err := os.ReadFile(filepath.Join("testdata", "reflow_expected.txt"))
+    if err != nil {
+        t.Fatalf("Failed to read expected fixture: %v", err)
+    }
+
+    got := postProcess(string(raw), Options{StripReferences: true, ReflowText: true, PageMarkers: true})
+    if got != string(expected) {
+        t.Errorf("postProcess() with ReflowText =\n%s\nwant\n%s", got, expected)
+    }
+}
@@ -0,0 +1,182 @@
+package convert
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// rtfDestinations lists the destinations whose content is not part of the document text
+var rtfDestinations = map[string]bool{
+	"fonttbl": true, "colortbl": true, "stylesheet": true, "info": true, "pict": true,
+	"header": true, "headerl": true, "headerr": true, "headerf": true,
+	"footer": true, "footerl": true, "footerr": true, "footerf": true,
+	"listtable": true, "listoverridetable": true, "revtbl": true, "rsidtbl": true,
+	"generator": true, "xmlnstbl": true, "themedata": true, "colorschememapping": true,
+	"datastore": true, "latentstyles": true, "object": true, "fldinst": true,
+}
+
+// rtfSymbols maps control words to the text they stand for
+var rtfSymbols = map[string]string{
+	"par": "\n", "line": "\n", "sect": "\n", "page": "\n", "row": "\n",
+	"tab": "\t", "cell": "\t",
+	"emdash": "—", "endash": "–", "bullet": "•",
+	"lquote": "‘", "rquote": "’", "ldblquote": "“", "rdblquote": "”",
+}
+
+// cp1252 maps the bytes 0x80-0x9F of windows-1252, used by \'hh escapes, to their Unicode code points.
+// The remaining bytes match Latin-1.
+var cp1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x88: 'ˆ', 0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž',
+	0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+	0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›', 0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+func readRtf(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(strings.TrimSpace(string(content)), "{\\rtf") {
+		return "", fmt.Errorf("not an RTF document: %s", path)
+	}
+	return rtfToText(string(content)), nil
+}
+
+// rtfToText strips control words and groups from an RTF document and returns its plain text
+func rtfToText(rtf string) string {
+	type groupState struct {
+		skip bool // the group is an ignored destination
+		uc   int  // number of fallback characters following a \u escape
+	}
+	var textBuilder strings.Builder
+	state := groupState{uc: 1}
+	var stack []groupState
+	toSkip := 0 // fallback characters still to skip after a \u escape
+
+	write := func(s string) {
+		if !state.skip {
+			textBuilder.WriteString(s)
+		}
+	}
+
+	for i := 0; i < len(rtf); i++ {
+		c := rtf[i]
+		switch c {
+		case '{':
+			toSkip = 0
+			stack = append(stack, state)
+		case '}':
+			toSkip = 0
+			if len(stack) > 0 {
+				state = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case '\r', '\n':
+			// line breaks in the source are not significant
+		case '\\':
+			if i+1 >= len(rtf) {
+				break
+			}
+			next := rtf[i+1]
+			switch {
+			case next == '\\' || next == '{' || next == '}':
+				i++
+				if toSkip > 0 {
+					toSkip--
+				} else {
+					write(rtf[i : i+1])
+				}
+			case next == '\'':
+				// hexadecimal escape of a windows-1252 byte
+				i++
+				if i+2 < len(rtf) {
+					value, err := strconv.ParseUint(rtf[i+1:i+3], 16, 8)
+					i += 2
+					if err != nil {
+						break
+					}
+					if toSkip > 0 {
+						toSkip--
+						break
+					}
+					if r, ok := cp1252[byte(value)]; ok {
+						write(string(r))
+					} else {
+						write(string(rune(value)))
+					}
+				}
+			case next == '*':
+				// the group is an optional destination unknown to most readers
+				i++
+				state.skip = true
+			case next == '~':
+				i++
+				write(" ")
+			case next == '_':
+				i++
+				write("-")
+			case next == '\r' || next == '\n':
+				i++
+				write("\n")
+			case isASCIILetter(next):
+				// control word with optional numeric parameter and space delimiter
+				j := i + 1
+				for j < len(rtf) && isASCIILetter(rtf[j]) {
+					j++
+				}
+				word := rtf[i+1 : j]
+				k := j
+				if k < len(rtf) && rtf[k] == '-' {
+					k++
+				}
+				for k < len(rtf) && rtf[k] >= '0' && rtf[k] <= '9' {
+					k++
+				}
+				param, hasParam := 0, false
+				if k > j {
+					if value, err := strconv.Atoi(rtf[j:k]); err == nil {
+						param, hasParam = value, true
+					}
+				}
+				if k < len(rtf) && rtf[k] == ' ' {
+					k++
+				}
+				i = k - 1
+
+				switch {
+				case rtfDestinations[word]:
+					state.skip = true
+				case word == "uc" && hasParam:
+					state.uc = param
+				case word == "u" && hasParam:
+					if param < 0 {
+						param += 65536
+					}
+					write(string(rune(param)))
+					toSkip = state.uc
+				default:
+					if symbol, ok := rtfSymbols[word]; ok {
+						write(symbol)
+					}
+				}
+			default:
+				// other control symbols, e.g. \- optional hyphen
+				i++
+			}
+		default:
+			if toSkip > 0 {
+				toSkip--
+				continue
+			}
+			write(rtf[i : i+1])
+		}
+	}
+	return textBuilder.String()
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
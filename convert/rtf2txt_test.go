@@ -0,0 +1,48 @@
+package convert
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestReadRtf(t *testing.T) {
+    rtfContent := `{\rtf1\ansi\ansicpg1252\deff0\uc1
+{\fonttbl{\f0\fswiss Helvetica;}{\f1\froman Times New Roman;}}
+{\colortbl;\red0\green0\blue0;\red255\green0\blue0;}
+{\*\generator Riched20 10.0.19041}
+{\info{\title Test document}{\author John Doe}}
+\pard\f0\fs24 This is {\b bold} and {\i italic} text.\par
+Caf\'e9 and na\u239?ve r\u233?sum\u233?, \u-3913?\par
+Escaped \{braces\} and a backslash \\.\par
+Tab\tab separated\line
+new line\par
+}`
+    rtfPath := filepath.Join(t.TempDir(), "test.rtf")
+    if err := os.WriteFile(rtfPath, []byte(rtfContent), 0644); err != nil {
+        t.Fatalf("Failed to write test RTF file: %v", err)
+    }
+
+    got, err := readRtf(rtfPath)
+    if err != nil {
+        t.Fatalf("readRtf returned an error: %v", err)
+    }
+
+    expected := "This is bold and italic text.\n" +
+        "Café and naïve résumé, \uF0B7\n" +
+        "Escaped {braces} and a backslash \\.\n" +
+        "Tab\tseparated\nnew line\n"
+    if got != expected {
+        t.Errorf("readRtf() = %q, want %q", got, expected)
+    }
+}
+
+func TestReadRtfInvalidFile(t *testing.T) {
+    invalidPath := filepath.Join(t.TempDir(), "invalid.rtf")
+    if err := os.WriteFile(invalidPath, []byte("plain text, not RTF"), 0644); err != nil {
+        t.Fatalf("Failed to write test file: %v", err)
+    }
+    if _, err := readRtf(invalidPath); err == nil {
+        t.Error("Expected an error for a file that is not RTF, got nil")
+    }
+}
@@ -0,0 +1,92 @@
+package convert
+
+import (
+	"regexp"
+	"strings"
+)
+
+// abstractHeading matches a line starting the abstract, e.g. "Abstract", "ABSTRACT:", "Abstract. Text..." or
+// "Summary:", capturing any text following the heading on the same line.
+var abstractHeading = regexp.MustCompile(`(?i)^(?:abstract\b[ \t]*[:.\-–—]?|summary[ \t]*(?::|$))[ \t]*(.*)$`)
+
+// abstractEndHeading matches a line ending the abstract, such as the keywords or the introduction heading,
+// optionally numbered (e.g., "1. Introduction").
+var abstractEndHeading = regexp.MustCompile(`(?i)^(?:[0-9IVX]+\.?[ \t]*)?(?:introduction|background|keywords?|key[ \t]+words|index[ \t]+terms)\b`)
+
+// markerLine matches the page and slide markers added by the conversion.
+var markerLine = regexp.MustCompile(`^--- (?:Page|Slide) \d+ ---$`)
+
+// ExtractSections splits a converted text into its title, abstract and body with simple heuristics.
+// The title is the first non-empty line. The abstract is the text following an "Abstract" heading up to
+// the keywords or introduction heading, or up to the first blank line when none of them is found, and
+// is returned on a single line. The body is the text following the abstract, or the title when no
+// abstract is found. Values are best effort and empty when a section cannot be located.
+//
+// Parameters:
+//   - text: The text of a converted document.
+//
+// Returns:
+//   - The title, the abstract and the body of the document.
+func ExtractSections(text string) (title, abstract, body string) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	titleLine := -1
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || markerLine.MatchString(line) {
+			continue
+		}
+		title = line
+		titleLine = i
+		break
+	}
+	if titleLine < 0 {
+		return "", "", ""
+	}
+
+	abstractStart := -1
+	var abstractLines []string
+	for i := titleLine + 1; i < len(lines); i++ {
+		if match := abstractHeading.FindStringSubmatch(strings.TrimSpace(lines[i])); match != nil {
+			abstractStart = i
+			if rest := strings.TrimSpace(match[1]); rest != "" {
+				abstractLines = append(abstractLines, rest)
+			}
+			break
+		}
+	}
+	if abstractStart < 0 {
+		return title, "", strings.TrimSpace(strings.Join(lines[titleLine+1:], "\n"))
+	}
+
+	// Collect the abstract up to an end heading, or up to the first blank line if there is no end heading
+	hasEndHeading := false
+	for _, line := range lines[abstractStart+1:] {
+		if abstractEndHeading.MatchString(strings.TrimSpace(line)) {
+			hasEndHeading = true
+			break
+		}
+	}
+	bodyStart := len(lines)
+	for i := abstractStart + 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if abstractEndHeading.MatchString(line) {
+			bodyStart = i
+			break
+		}
+		if line == "" || markerLine.MatchString(line) {
+			if !hasEndHeading && len(abstractLines) > 0 {
+				bodyStart = i
+				break
+			}
+			continue
+		}
+		abstractLines = append(abstractLines, line)
+	}
+
+	abstract = strings.Join(abstractLines, " ")
+	if bodyStart < len(lines) {
+		body = strings.TrimSpace(strings.Join(lines[bodyStart:], "\n"))
+	}
+	return title, abstract, body
+}
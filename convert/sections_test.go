@@ -0,0 +1,69 @@
+package convert
+
+import (
+    "testing"
+)
+
+func TestExtractSections(t *testing.T) {
+    tests := []struct {
+        name     string
+        text     string
+        title    string
+        abstract string
+        body     string
+    }{
+        {
+            name: "abstract heading up to keywords",
+            text: "\n--- Page 1 ---\nRiver Basin Modelling at Scale\nJane Doe, John Smith\n\nAbstract\nWe model river basins.\nResults are robust.\n\nKeywords: hydrology, models\n1. Introduction\nRivers matter.",
+            title:    "River Basin Modelling at Scale",
+            abstract: "We model river basins. Results are robust.",
+            body:     "Keywords: hydrology, models\n1. Introduction\nRivers matter.",
+        },
+        {
+            name:     "inline abstract up to introduction",
+            text:     "Interest Rates and Growth\nABSTRACT: Rates affect growth.\nINTRODUCTION\nThe body.",
+            title:    "Interest Rates and Growth",
+            abstract: "Rates affect growth.",
+            body:     "INTRODUCTION\nThe body.",
+        },
+        {
+            name:     "abstract ends at blank line without end heading",
+            text:     "A Short Note\nAbstract. A brief note.\n\nMain text follows.",
+            title:    "A Short Note",
+            abstract: "A brief note.",
+            body:     "Main text follows.",
+        },
+        {
+            name:  "no abstract",
+            text:  "Report Title\nSome text.\nMore text.",
+            title: "Report Title",
+            body:  "Some text.\nMore text.",
+        },
+        {
+            name:     "summary heading",
+            text:     "Policy Brief\nSummary statistics are reported below.\nSummary:\nThe brief in short.\nBackground\nDetails.",
+            title:    "Policy Brief",
+            abstract: "The brief in short.",
+            body:     "Background\nDetails.",
+        },
+        {
+            name: "empty text",
+            text: "  \n\n",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            title, abstract, body := ExtractSections(tt.text)
+            if title != tt.title {
+                t.Errorf("title = %q, want %q", title, tt.title)
+            }
+            if abstract != tt.abstract {
+                t.Errorf("abstract = %q, want %q", abstract, tt.abstract)
+            }
+            if body != tt.body {
+                t.Errorf("body = %q, want %q", body, tt.body)
+            }
+        })
+    }
+}
@@ -41,6 +41,11 @@ func ComputeCosts(prompts []string, provider string, model string, key string) s
 
 func assessPromptCost(prompt string, provider string, model string, key string) (decimal.Decimal, error) {
 	numTokens := tokenCounter.GetNumTokensFromPrompt(prompt, provider, model, key)
+	if numTokens == 0 && prompt != "" {
+		// the provider could not count the tokens, fall back to an approximation
+		numTokens = tokens.EstimateTokens(prompt, provider)
+		log.Println("Token count unavailable, using estimate of", numTokens, "tokens")
+	}
 	numCents := numCentsFromTokens(numTokens, model)
 	return numCents, nil
 }
@@ -63,3 +63,23 @@ func TestComputeCosts(t *testing.T) {
         t.Errorf("Total cost mismatch. Expected: %s, Got: %s", expectedTotalCost.String(), totalCost.String())
     }
 }
+
+func TestAssessPromptCostFallsBackToEstimate(t *testing.T) {
+    originalTokenCounter := tokenCounter
+    defer func() { tokenCounter = originalTokenCounter }()
+
+    // The mock returns 0 tokens for unknown prompts, as the real counter does on errors
+    tokenCounter = &MockTokenCounter{TokensPerPrompt: map[string]int{}}
+
+    prompt := "This prompt has forty characters in it.."
+    model := "gpt-4o-mini"
+
+    got, err := assessPromptCost(prompt, "OpenAI", model, "test-api-key")
+    if err != nil {
+        t.Fatalf("assessPromptCost returned an error: %v", err)
+    }
+    expected := numCentsFromTokens(10, model)
+    if !got.Equal(expected) {
+        t.Errorf("Cost mismatch. Expected: %s, Got: %s", expected.String(), got.String())
+    }
+}
@@ -5,8 +5,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/open-and-sustainable/prismaid/config"
+
 	prompt "github.com/cqroot/prompt"
 	choose "github.com/cqroot/prompt/choose"
 	input "github.com/cqroot/prompt/input"
@@ -29,13 +32,26 @@ type ModelItem struct {
 	RpmLimit	string
 }
 
+// Options defines optional settings of the interactive configuration creation.
+type Options struct {
+	PreviewOnly bool // return the generated TOML without asking for confirmation and without writing it
+}
+
 // RunInteractiveConfig launches an interactive terminal session to collect project configuration information 
 // from the user. It utilizes advanced prompt features to provide a user-friendly way of setting key project 
 // settings.
 //
 // This function offers different types of prompts such as single and multiple choices, allowing the user 
-// to customize configurations based on project requirements.
+// to customize configurations based on project requirements. The generated configuration is printed and
+// written to file only after the user confirms it.
 func RunInteractiveConfigCreation() {
+	RunInteractiveConfigCreationWithOptions(Options{})
+}
+
+// RunInteractiveConfigCreationWithOptions behaves like RunInteractiveConfigCreation, applying the settings in
+// options, and returns the generated TOML configuration. With options.PreviewOnly the configuration is
+// returned without the final confirmation and without being written to file.
+func RunInteractiveConfigCreationWithOptions(options Options) string {
 	fmt.Println("Running interactive project configuration initialization...")
 
 	// Ask for file path to save the configuration
@@ -108,7 +124,7 @@ func RunInteractiveConfigCreation() {
 		// inputConversion
 		val2, err := prompt.New().Ask("Do you need input file conversion from these formats to .txt? (leave empty if not needed)").
 			MultiChoose(
-				[]string{"pdf", "docx", "html"},
+				[]string{"pdf", "docx", "html", "pptx", "rtf"},
 				multichoose.WithDefaultIndexes(1, []int{}),
 				multichoose.WithHelp(true),
 			)
@@ -179,11 +195,23 @@ func RunInteractiveConfigCreation() {
 
 	// Build models object
 	models_items := collectModelItems()
+	if len(models_items) == 0 {
+		// A review cannot run without models, offer to add them before going on
+		addModels, err := prompt.New().Ask("No generative AI model configured, the review cannot run without one. Do you want to add a model now?").
+			AdvancedChoose(
+				[]choose.Choice{
+					{Text: "yes", Note: "Configure at least one model now."},
+					{Text: "no", Note: "I will add the [project.llm] section to the configuration file manually."},
+				},
+				choose.WithHelp(true),)
+		checkErr(err)
+		if addModels == "yes" {
+			models_items = collectModelItems()
+		}
+	}
 	models := ""
 	if len(models_items) > 0 {
 		models = generateModelToml(models_items)
-	} else {
-		fmt.Println("You will have to specify the LLM parameters in your project configuration file.")
 	}
 
 	// Prompt for persona part of prompt
@@ -304,13 +332,66 @@ func RunInteractiveConfigCreation() {
 		failsafe, definitions, example, review,
 	)
 
+	if options.PreviewOnly {
+		return config
+	}
+
+	// Avoid clobbering an existing configuration without the user consent
+	filePath, overwrite := resolveExistingConfigPath(filePath)
+
+	// Warn about settings that would make the review fail before writing the configuration
+	if err := validateModels(models_items); err != nil {
+		fmt.Printf("Warning: %v\nYou will have to fix the [project.llm] section of %s before running the review.\n", err, filePath)
+	}
+
+	// Show the configuration and ask for confirmation before writing it
+	if !confirmConfigWrite(config, filePath) {
+		fmt.Println("Configuration file not written.")
+		return config
+	}
+
 	// Write the configuration to file
-	err = writeTomlConfigToFile(config, filePath)
+	err = writeTomlConfigToFile(config, filePath, overwrite)
 	if err != nil {
 		fmt.Println("Error writing configuration file:", err)
 	} else {
 		fmt.Println("Configuration file created successfully at:", filePath)
 	}
+	return config
+}
+
+// resolveExistingConfigPath asks the user, when filePath already exists, whether to overwrite it or to enter
+// a different path. It returns the path to write and whether an existing file may be overwritten.
+func resolveExistingConfigPath(filePath string) (string, bool) {
+	for {
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			return filePath, false
+		}
+		choice, err := prompt.New().Ask(fmt.Sprintf("File %s already exists. What do you want to do?", filePath)).
+			AdvancedChoose(
+				[]choose.Choice{
+					{Text: "new path", Note: "I will provide a different file path."},
+					{Text: "overwrite", Note: "Replace the existing configuration file."},
+				},
+				choose.WithHelp(true),)
+		checkErr(err)
+		if choice == "overwrite" {
+			return filePath, true
+		}
+		filePath, err = prompt.New().Ask("Enter file path to save the configuration:").Input(
+			filePath, input.WithHelp(true), input.WithValidateFunc(validatePath))
+		checkErr(err)
+	}
+}
+
+// confirmConfigWrite prints the generated configuration and asks the user whether to write it to filePath
+func confirmConfigWrite(config, filePath string) bool {
+	fmt.Printf("Generated configuration:\n\n%s\n\n", config)
+	answer, err := prompt.New().Ask(fmt.Sprintf("Write this configuration to %s? (yes/no)", filePath)).
+		Choose([]string{"yes", "no"},
+		choose.WithHelp(true),)
+	checkErr(err)
+	return answer == "yes"
 }
 
 func collectModelItems() []ModelItem {
@@ -330,14 +411,12 @@ func collectModelItems() []ModelItem {
 		}
 
 		// LLM provider selection with help
+		providerChoices := []choose.Choice{}
+		for _, supported := range config.SupportedProviders() {
+			providerChoices = append(providerChoices, choose.Choice{Text: supported, Note: providerNotes[supported]})
+		}
 		provider, err := prompt.New().Ask("Choose LLM provider:").
-		AdvancedChoose(
-			[]choose.Choice{
-				{Text: "OpenAI", Note: "OpenAI GPT-3 or GPT-4 models."},
-				{Text: "GoogleAI", Note: "GoogleAI Gemini models."},
-				{Text: "Cohere", Note: "Cohere language models."},
-				{Text: "Anthropic", Note: "Anthropic Claude models."},
-			},
+		AdvancedChoose(providerChoices,
 			choose.WithHelp(true),)
 		checkErr(err)
 
@@ -346,56 +425,24 @@ func collectModelItems() []ModelItem {
 		checkErr(err)
 
 		// Model choice for the selected LLM provider
-		model := ""
-		if provider == "OpenAI" {
-			model, err = prompt.New().Ask("Enter model to be used:").AdvancedChoose(
-				[]choose.Choice{
-					{Text: "", Note: "Model chosen automatically to minimize costs."},
-					{Text: "gpt-3.5-turbo", Note: "GPT-3.5 Turbo."},
-					{Text: "gpt-4-turbo", Note: "GPT-4 Turbo."},
-					{Text: "gpt-4o", Note: "GPT-4 Omni."},
-					{Text: "gpt-4o-mini", Note: "GPT-4 Omni Mini."},
-				},
-				choose.WithHelp(true),)
-
-		} else if provider == "GoogleAI" {
-			model, err = prompt.New().Ask("Enter model to be used:").AdvancedChoose(
-				[]choose.Choice{
-					{Text: "", Note: "Model chosen automatically to minimize costs."},
-					{Text: "gemini-1.0-pro", Note: "Gemini 1.0 Pro."},
-					{Text: "gemini-1.5-pro", Note: "Gemini 1.5 Pro."},
-					{Text: "gemini-1.5-flash", Note: "Gemini 1.5 Flash."},
-				},
-				choose.WithHelp(true),)
-		} else if provider == "Cohere" {
-			model, err = prompt.New().Ask("Enter model to be used:").AdvancedChoose(
-				[]choose.Choice{
-					{Text: "", Note: "Model chosen automatically to minimize costs."},
-					{Text: "command", Note: "Command."},
-					{Text: "command-light", Note: "Command Light."},
-					{Text: "command-r", Note: "Command R."},
-					{Text: "command-r-plus", Note: "Command R+."},
-				},
-				choose.WithHelp(true),)
-		} else if provider == "Anthropic" {
-			model, err = prompt.New().Ask("Enter model to be used:").AdvancedChoose(
-				[]choose.Choice{
-					{Text: "", Note: "Model chosen automatically to minimize costs."},
-					{Text: "claude-3-haiku", Note: "Claude 3 Haiku."},
-					{Text: "claude-3-sonnet", Note: "Claude 3 Sonnet."},
-					{Text: "claude-3-opus", Note: "Claude 3 Opus."},
-					{Text: "claude-3-5-haiku", Note: "Claude 3.5 Haiku."},
-					{Text: "claude-3-5-sonnet", Note: "Claude 3.5 Sonnet."},
-				},
-				choose.WithHelp(true),)
+		modelChoices := []choose.Choice{{Text: "", Note: "Model chosen automatically to minimize costs."}}
+		for _, supported := range config.ModelsForProvider(provider) {
+			modelChoices = append(modelChoices, choose.Choice{Text: supported, Note: modelNote(supported)})
 		}
+		model, err := prompt.New().Ask("Enter model to be used:").AdvancedChoose(modelChoices,
+			choose.WithHelp(true),)
 		checkErr(err)
 
 		// Prompt for model temperature
-		temperature, err := prompt.New().Ask("Enter model temperature (usually between 0 and 1 or 2):").Input(
+		temperature, err := prompt.New().Ask(fmt.Sprintf("Enter model temperature (between 0 and %g for %s):", config.MaxTemperature(provider), provider)).Input(
 			"0",
-			input.WithHelp(true), input.WithValidateFunc(validateNonNegative))
+			input.WithHelp(true), input.WithValidateFunc(temperatureValidator(provider)))
 		checkErr(err)
+		if value, err := strconv.ParseFloat(temperature, 64); err == nil {
+			if _, warning := config.CheckTemperature(provider, model, value); warning != "" {
+				fmt.Println("Warning:", warning)
+			}
+		}
 
 		// Prompt for tpm limit
 		tpmLimit, err := prompt.New().Ask("Enter maximum token per minute (0 to disable):").Input(
@@ -425,6 +472,51 @@ func collectModelItems() []ModelItem {
 	return modelItems
 }
 
+// providerNotes describes the supported providers in the provider selection prompt
+var providerNotes = map[string]string{
+	"OpenAI":    "OpenAI GPT-3 or GPT-4 models.",
+	"GoogleAI":  "GoogleAI Gemini models.",
+	"Cohere":    "Cohere language models.",
+	"Anthropic": "Anthropic Claude models.",
+}
+
+// modelNotes describes the supported models in the model selection prompt
+var modelNotes = map[string]string{
+	"gpt-3.5-turbo":     "GPT-3.5 Turbo.",
+	"gpt-4-turbo":       "GPT-4 Turbo.",
+	"gpt-4o":            "GPT-4 Omni.",
+	"gpt-4o-mini":       "GPT-4 Omni Mini.",
+	"gemini-1.0-pro":    "Gemini 1.0 Pro.",
+	"gemini-1.5-pro":    "Gemini 1.5 Pro.",
+	"gemini-1.5-flash":  "Gemini 1.5 Flash.",
+	"command":           "Command.",
+	"command-light":     "Command Light.",
+	"command-r":         "Command R.",
+	"command-r-plus":    "Command R+.",
+	"claude-3-haiku":    "Claude 3 Haiku.",
+	"claude-3-sonnet":   "Claude 3 Sonnet.",
+	"claude-3-opus":     "Claude 3 Opus.",
+	"claude-3-5-haiku":  "Claude 3.5 Haiku.",
+	"claude-3-5-sonnet": "Claude 3.5 Sonnet.",
+}
+
+// modelNote returns the description of a model in the model selection prompt, defaulting to its name
+func modelNote(model string) string {
+	if note, ok := modelNotes[model]; ok {
+		return note
+	}
+	return model + "."
+}
+
+// validateModels checks the collected models with config.Validate, as done when the review starts
+func validateModels(modelsItems []ModelItem) error {
+	llm := make(map[string]config.LLMItem, len(modelsItems))
+	for i, item := range modelsItems {
+		llm[strconv.Itoa(i+1)] = config.LLMItem{Provider: item.Provider, Model: item.Model}
+	}
+	return config.Validate(&config.Config{Project: config.ProjectConfig{LLM: llm}})
+}
+
 func generateModelToml(modelsItems []ModelItem) string {
 	var tomlModelsSection strings.Builder
 
@@ -580,6 +672,8 @@ summary = "%s"
 user = "%s"
 api_key = "%s"
 group = "%s" 
+download_summary = "no"
+auth_mode = "api_key"
 
 [project.llm]
 %s
@@ -599,10 +693,22 @@ persona, task, expected_result, failsafe, definitions, example, review)
 	return strings.TrimSpace(config)
 }
 
-// Helper function to write the TOML configuration to a file
-func writeTomlConfigToFile(config, filePath string) error {
-	file, err := os.Create(filePath)
-	if err != nil {
+// WriteConfig writes a TOML configuration, e.g. as returned by RunInteractiveConfigCreationWithOptions, to filePath.
+// When overwrite is false and filePath already exists, the file is left untouched and an error is returned.
+func WriteConfig(config, filePath string, overwrite bool) error {
+	return writeTomlConfigToFile(config, filePath, overwrite)
+}
+
+// Helper function to write the TOML configuration to a file, refusing to replace an existing file unless overwrite is set
+func writeTomlConfigToFile(config, filePath string, overwrite bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	}
+	file, err := os.OpenFile(filePath, flags, 0644)
+	if os.IsExist(err) {
+		return fmt.Errorf("configuration file %s already exists: %w", filePath, err)
+	} else if err != nil {
 		return err
 	}
 	defer file.Close()
@@ -680,6 +786,23 @@ func validateFileName(fileName string) error {
 	return nil
 }
 
+// temperatureValidator returns a validation function accepting the temperatures supported by the provider
+func temperatureValidator(provider string) func(string) error {
+	return func(value string) error {
+		if err := validateNonNegative(value); err != nil {
+			return err
+		}
+		temperature, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("value must be a number")
+		}
+		if max := config.MaxTemperature(provider); temperature > max {
+			return fmt.Errorf("%s accepts temperatures between 0 and %g", provider, max)
+		}
+		return nil
+	}
+}
+
 func validateNonNegative(value string) error {
 	if value == "" {
 		return fmt.Errorf("value cannot be empty")
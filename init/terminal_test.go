@@ -1,8 +1,10 @@
 package init
 
 import (
+    "errors"
     "fmt"
     "os"
+    "path/filepath"
     "strings"
     "testing"
 )
@@ -76,3 +78,31 @@ func myInteractiveFunction() (string, error) {
     }
     return userInput, nil
 }
+
+func TestWriteConfigOverwrite(t *testing.T) {
+    filePath := filepath.Join(t.TempDir(), "config.toml")
+
+    if err := WriteConfig("first", filePath, false); err != nil {
+        t.Fatalf("WriteConfig returned an error on a new file: %v", err)
+    }
+
+    err := WriteConfig("second", filePath, false)
+    if err == nil {
+        t.Fatal("Expected an error when writing over an existing file without overwrite")
+    }
+    if !errors.Is(err, os.ErrExist) {
+        t.Errorf("Expected an os.ErrExist error, got %v", err)
+    }
+    content, _ := os.ReadFile(filePath)
+    if string(content) != "first" {
+        t.Errorf("Existing file was modified: got %q", content)
+    }
+
+    if err := WriteConfig("third", filePath, true); err != nil {
+        t.Fatalf("WriteConfig returned an error with overwrite: %v", err)
+    }
+    content, _ = os.ReadFile(filePath)
+    if string(content) != "third" {
+        t.Errorf("Expected overwritten content %q, got %q", "third", content)
+    }
+}
@@ -0,0 +1,195 @@
+package prismaid
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/open-and-sustainable/prismaid/config"
+	"github.com/open-and-sustainable/prismaid/convert"
+	"github.com/open-and-sustainable/prismaid/debug"
+)
+
+// Pipeline stages supported in the [pipeline] section of the project configuration
+const (
+	StageDownload = "download"
+	StageConvert  = "convert"
+	StageReview   = "review"
+)
+
+var pipelineStages = []string{StageDownload, StageConvert, StageReview}
+
+// pipelineConfig holds the [pipeline] section of the project configuration.
+type pipelineConfig struct {
+	Pipeline struct {
+		Stages  []string              `toml:"stages"`
+		Convert pipelineConvertConfig `toml:"convert"`
+	} `toml:"pipeline"`
+}
+
+// pipelineConvertConfig holds the [pipeline.convert] settings of the convert stage.
+type pipelineConvertConfig struct {
+	OutputDir       string `toml:"output_dir"`
+	ReflowText      string `toml:"reflow_text"`
+	StripReferences string `toml:"strip_references"`
+}
+
+// Pipeline runs the stages listed in the [pipeline] section of the project configuration, in order,
+// passing the output directory of each stage as input to the next one. For example:
+//
+//	[pipeline]
+//	stages = ["download", "convert", "review"]
+//
+// The stages use the settings of the rest of the project configuration:
+//   - download: Downloads the PDFs of the collection or group in [project.zotero] to the zotero subdirectory
+//     of the results directory, which becomes the input directory of the following stages. The "pdf" format
+//     is added to the formats converted by the following convert stages.
+//   - convert: Converts the files in the input directory to text, using the formats in input_conversion
+//     and the optional [pipeline.convert] table:
+//
+//	[pipeline.convert]
+//	output_dir = "./converted"  # write the text files here, which becomes the input directory of the following stages
+//	reflow_text = "yes"         # join hard wrapped lines within paragraphs
+//	strip_references = "yes"    # drop the trailing references section
+//
+//   - review: Runs the review of the text files in the input directory, as RunReview, without repeating
+//     the download and conversion already carried out by previous stages. Without a previous convert stage,
+//     the review converts the input directory as set in input_conversion, as RunReview does. A review
+//     following a download needs a convert stage in between, since the downloaded PDFs are not text.
+//
+// Only the convert stage has its own settings table, the download and review stages are configured by the
+// [project] sections only. The models in [project.llm] are required only when a review stage is listed.
+//
+// Parameters:
+//   - tomlConfiguration: A string containing the TOML configuration data, including the [pipeline] section.
+//
+// Returns:
+//   - An error labelled with the failing stage, or nil if all the stages complete successfully. The pipeline
+//     stops at the first failing stage.
+func Pipeline(tomlConfiguration string) error {
+	var pipeline pipelineConfig
+	if _, err := toml.Decode(tomlConfiguration, &pipeline); err != nil {
+		return fmt.Errorf("error decoding pipeline configuration: %w", err)
+	}
+	stages := pipeline.Pipeline.Stages
+	if len(stages) == 0 {
+		return fmt.Errorf("no stages listed in the [pipeline] section, supported stages are: %s", strings.Join(pipelineStages, ", "))
+	}
+	downloaded, converted, reviewed := false, false, false
+	for i, stage := range stages {
+		switch stage {
+		case StageDownload:
+			downloaded, converted = true, false
+		case StageConvert:
+			converted = true
+		case StageReview:
+			reviewed = true
+			if downloaded && !converted {
+				return fmt.Errorf("pipeline stage %d (%s): no text to review, add a %s stage after the %s stage", i+1, stage, StageConvert, StageDownload)
+			}
+		default:
+			return fmt.Errorf("pipeline stage %d (%s): unsupported stage, supported stages are: %s", i+1, stage, strings.Join(pipelineStages, ", "))
+		}
+	}
+
+	cfg, err := config.LoadConfig(tomlConfiguration, config.RealEnvReader{})
+	if err != nil {
+		return fmt.Errorf("error loading project configuration: %w", err)
+	}
+	if reviewed {
+		if err := config.Validate(cfg); err != nil {
+			return fmt.Errorf("error in project configuration: %w", err)
+		}
+	}
+	setupLogging(cfg)
+	if reviewed {
+		logTemperatureWarnings(cfg)
+	}
+
+	convertOptions := conversionOptions(cfg, pipeline.Pipeline.Convert.OutputDir)
+	convertOptions.ReflowText = pipeline.Pipeline.Convert.ReflowText == "yes"
+	convertOptions.StripReferences = pipeline.Pipeline.Convert.StripReferences == "yes"
+
+	inputDir := cfg.Project.Configuration.InputDirectory
+	formats := cfg.Project.Configuration.InputConversion
+	converted = false
+	for i, stage := range stages {
+		log.Printf("Pipeline stage %d (%s) started", i+1, stage)
+		switch stage {
+		case StageDownload:
+			if cfg.Project.Zotero.User == "" {
+				err = fmt.Errorf("the [project.zotero] section has no user")
+				break
+			}
+			err = downloadZoteroPDFs(cfg)
+			inputDir = filepath.Join(getDirectoryPath(cfg.Project.Configuration.ResultsFileName), "zotero")
+			formats = withFormat(formats, "pdf")
+		case StageConvert:
+			if formats == "no" {
+				err = fmt.Errorf("no formats to convert, set input_conversion in [project.configuration]")
+				break
+			}
+			err = convert.ConvertWithOptions(inputDir, formats, convertOptions)
+			converted = err == nil
+			if converted && convertOptions.OutputDir != "" {
+				inputDir = convertOptions.OutputDir
+			}
+		case StageReview:
+			err = runPipelineReview(*cfg, inputDir, converted)
+		}
+		if err != nil {
+			log.Printf("Pipeline stage %d (%s) failed: %v", i+1, stage, err)
+			return fmt.Errorf("pipeline stage %d (%s): %w", i+1, stage, err)
+		}
+	}
+
+	return nil
+}
+
+// logTemperatureWarnings lowers the model temperatures above the provider maximum, logging a warning for
+// each adjusted or ignored temperature
+func logTemperatureWarnings(cfg *config.Config) {
+	for _, warning := range config.CheckTemperatures(cfg) {
+		log.Println("Warning:", warning)
+	}
+}
+
+// withFormat adds format to the comma separated formats of input_conversion, if missing, e.g. so that the
+// PDFs downloaded from Zotero are converted.
+func withFormat(formats, format string) string {
+	if formats == "no" || formats == "" {
+		return format
+	}
+	for _, f := range strings.Split(formats, ",") {
+		if f == format {
+			return formats
+		}
+	}
+	return formats + "," + format
+}
+
+// setupLogging configures the logging output from the log_level of the project configuration
+func setupLogging(cfg *config.Config) {
+	if cfg.Project.Configuration.LogLevel == "high" {
+		debug.SetupLogging(debug.File, cfg.Project.Configuration.ResultsFileName)
+	} else if cfg.Project.Configuration.LogLevel == "medium" {
+		debug.SetupLogging(debug.Stdout, cfg.Project.Configuration.ResultsFileName)
+	} else {
+		debug.SetupLogging(debug.Silent, cfg.Project.Configuration.ResultsFileName) // default value
+	}
+}
+
+// runPipelineReview runs the review of the text files in inputDir. When a previous convert stage already
+// produced the text, download and conversion are disabled, otherwise the review converts the input as set
+// in the project configuration. Logging is already set up by Pipeline.
+func runPipelineReview(cfg config.Config, inputDir string, converted bool) error {
+	cfg.Project.Configuration.InputDirectory = inputDir
+	if converted {
+		cfg.Project.Configuration.InputConversion = "no"
+		cfg.Project.Zotero = config.ProjectZotero{}
+	}
+
+	return runReview(&cfg, ReviewOptions{})
+}
@@ -0,0 +1,147 @@
+package prismaid
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+const mockPipelineConfigTemplate = `
+[pipeline]
+stages = [%s]
+
+[project.configuration]
+input_directory = "%s"
+input_conversion = "html"
+results_file_name = "%s/test_results"
+log_level = "low"
+
+[project.llm.1]
+provider = "OpenAI"
+api_key = "test-api-key"
+model = "gpt-4o-mini"
+`
+
+func TestPipelineStageValidation(t *testing.T) {
+    tmpDir := t.TempDir()
+
+    tests := []struct {
+        name   string
+        stages string
+        errMsg string
+    }{
+        {"no stages", ``, "no stages listed"},
+        {"unsupported stage", `"convert", "screen"`, "pipeline stage 2 (screen): unsupported stage"},
+        {"review of downloaded PDFs", `"download", "review"`, "pipeline stage 2 (review): no text to review"},
+        {"review after a new download", `"download", "convert", "download", "review"`, "pipeline stage 4 (review): no text to review"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := Pipeline(fmt.Sprintf(mockPipelineConfigTemplate, tt.stages, tmpDir, tmpDir))
+            if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+                t.Errorf("Pipeline() error = %v, want error containing %q", err, tt.errMsg)
+            }
+        })
+    }
+}
+
+func TestPipelineConvertStage(t *testing.T) {
+    tmpDir := t.TempDir()
+    htmlPath := filepath.Join(tmpDir, "paper.html")
+    if err := os.WriteFile(htmlPath, []byte("<html><body><p>Pipeline text</p></body></html>"), 0644); err != nil {
+        t.Fatalf("Failed to write test HTML file: %v", err)
+    }
+
+    err := Pipeline(fmt.Sprintf(mockPipelineConfigTemplate, `"convert"`, tmpDir, tmpDir))
+    if err != nil {
+        t.Fatalf("Pipeline returned an error: %v", err)
+    }
+
+    content, err := os.ReadFile(filepath.Join(tmpDir, "paper.txt"))
+    if err != nil {
+        t.Fatalf("Expected converted file: %v", err)
+    }
+    if !strings.Contains(string(content), "Pipeline text") {
+        t.Errorf("Unexpected converted content: %q", content)
+    }
+}
+
+func TestPipelineDownloadStageWithoutZotero(t *testing.T) {
+    tmpDir := t.TempDir()
+    err := Pipeline(fmt.Sprintf(mockPipelineConfigTemplate, `"download", "convert", "review"`, tmpDir, tmpDir))
+    if err == nil || !strings.Contains(err.Error(), "pipeline stage 1 (download)") {
+        t.Errorf("Pipeline() error = %v, want error labelled with the download stage", err)
+    }
+}
+
+const mockPipelineNoModelConfigTemplate = `
+[pipeline]
+stages = [%s]
+
+[pipeline.convert]
+output_dir = "%s"
+reflow_text = "yes"
+
+[project.configuration]
+input_directory = "%s"
+input_conversion = "html"
+results_file_name = "%s/test_results"
+log_level = "low"
+`
+
+func TestPipelineConvertStageWithoutModels(t *testing.T) {
+    tmpDir := t.TempDir()
+    outputDir := filepath.Join(tmpDir, "converted")
+    htmlPath := filepath.Join(tmpDir, "paper.html")
+    if err := os.WriteFile(htmlPath, []byte("<html><body><p>Pipeline\ntext</p></body></html>"), 0644); err != nil {
+        t.Fatalf("Failed to write test HTML file: %v", err)
+    }
+
+    err := Pipeline(fmt.Sprintf(mockPipelineNoModelConfigTemplate, `"convert"`, outputDir, tmpDir, tmpDir))
+    if err != nil {
+        t.Fatalf("Pipeline returned an error: %v", err)
+    }
+
+    content, err := os.ReadFile(filepath.Join(outputDir, "paper.txt"))
+    if err != nil {
+        t.Fatalf("Expected converted file in the output directory: %v", err)
+    }
+    if !strings.Contains(string(content), "Pipeline text") {
+        t.Errorf("Unexpected converted content: %q", content)
+    }
+    if _, err := os.Stat(filepath.Join(tmpDir, "paper.txt")); !os.IsNotExist(err) {
+        t.Errorf("Expected no converted file next to the source, got error %v", err)
+    }
+}
+
+func TestPipelineReviewWithoutModels(t *testing.T) {
+    tmpDir := t.TempDir()
+    err := Pipeline(fmt.Sprintf(mockPipelineNoModelConfigTemplate, `"convert", "review"`, tmpDir, tmpDir, tmpDir))
+    if err == nil || !strings.Contains(err.Error(), "no model configured") {
+        t.Errorf("Pipeline() error = %v, want error about the missing models", err)
+    }
+}
+
+func TestWithFormat(t *testing.T) {
+    tests := []struct {
+        formats  string
+        format   string
+        expected string
+    }{
+        {"no", "pdf", "pdf"},
+        {"", "pdf", "pdf"},
+        {"pdf", "pdf", "pdf"},
+        {"docx,pdf", "pdf", "docx,pdf"},
+        {"docx", "pdf", "docx,pdf"},
+        {"pdf", "pptx", "pdf,pptx"},
+    }
+
+    for _, tt := range tests {
+        if got := withFormat(tt.formats, tt.format); got != tt.expected {
+            t.Errorf("withFormat(%q, %q) = %q, want %q", tt.formats, tt.format, got, tt.expected)
+        }
+    }
+}
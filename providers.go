@@ -0,0 +1,18 @@
+package prismaid
+
+import (
+	"github.com/open-and-sustainable/prismaid/config"
+)
+
+// SupportedProviders returns the names of the supported AI providers (e.g., "OpenAI", "GoogleAI", "Cohere",
+// "Anthropic"), as accepted in the provider field of the project configuration.
+func SupportedProviders() []string {
+	return config.SupportedProviders()
+}
+
+// ModelsForProvider returns the models supported for the given provider, as accepted in the model field of
+// the project configuration, or nil if the provider is not supported. Leaving the model field empty selects
+// the cheapest model automatically.
+func ModelsForProvider(provider string) []string {
+	return config.ModelsForProvider(provider)
+}
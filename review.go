@@ -62,6 +62,7 @@ var mutex sync.Mutex
 //    - The configuration contains details such as the project settings, LLM models, input/output settings, 
 //      logging levels, and debugging options.
 //    - If the TOML data is invalid or an error occurs during parsing, the function logs the error and returns it.
+//    - The configuration is then checked with config.Validate, which rejects e.g. configurations without any model.
 //
 // 2. **Setup Logging**:
 //    - Based on the log level specified in the configuration (high, medium, or low), the function 
@@ -114,45 +115,85 @@ var mutex sync.Mutex
 // The RunReview function is the primary entry point for executing the entire review process, based on the user-provided TOML configuration string. 
 // It orchestrates the different stages of the review process, including input parsing, prompt generation, model interaction, and output management.
 func RunReview(tomlConfiguration string) error {
+	return RunReviewWithOptions(tomlConfiguration, ReviewOptions{})
+}
+
+// ReviewOptions defines settings of a review run that override the project configuration, e.g. from command line flags.
+type ReviewOptions struct {
+	LogLevel         string   // "low", "medium" or "high", replacing log_level of the project configuration when not empty
+	ConvertOutputDir string   // directory receiving the converted text files, which are then reviewed from there, "" keeps them next to the sources
+	ZoteroSummary    bool     // save the Zotero download summary as with download_summary = "yes" in the project configuration
+	ConvertFormats   []string // formats converted in addition to those in input_conversion, e.g. "pptx"
+}
+
+// RunReviewWithOptions behaves like RunReview, applying the overrides in reviewOptions to the project configuration.
+//
+// Parameters:
+//   - tomlConfiguration: A string containing the TOML configuration data for the review project.
+//   - reviewOptions: The ReviewOptions overriding the project configuration.
+//
+// Returns:
+//   - An error if any step in the review process fails, or nil if the process completes successfully.
+func RunReviewWithOptions(tomlConfiguration string, reviewOptions ReviewOptions) error {
 	// load project configuration
-	config, err := config.LoadConfig(tomlConfiguration, config.RealEnvReader{})
+	cfg, err := config.LoadConfig(tomlConfiguration, config.RealEnvReader{})
 	if err != nil {
 		fmt.Println("Error loading project configuration:", err) // here the logging function is not implemented yet
 		return err
 	}
+	// check the models before any file is downloaded or converted
+	if err := config.Validate(cfg); err != nil {
+		fmt.Println("Error in project configuration:", err)
+		return err
+	}
+	if reviewOptions.LogLevel != "" {
+		cfg.Project.Configuration.LogLevel = reviewOptions.LogLevel
+	}
+	if reviewOptions.ZoteroSummary {
+		cfg.Project.Zotero.DownloadSummary = "yes"
+	}
+	for _, format := range reviewOptions.ConvertFormats {
+		cfg.Project.Configuration.InputConversion = withFormat(cfg.Project.Configuration.InputConversion, format)
+	}
 
 	// setup logging
-	if config.Project.Configuration.LogLevel == "high" {
-		debug.SetupLogging(debug.File, config.Project.Configuration.ResultsFileName)
-	} else if config.Project.Configuration.LogLevel == "medium" {
-		debug.SetupLogging(debug.Stdout, config.Project.Configuration.ResultsFileName)
-	} else {
-		debug.SetupLogging(debug.Silent, config.Project.Configuration.ResultsFileName) // default value
-	}
+	setupLogging(cfg)
+	logTemperatureWarnings(cfg)
+
+	return runReview(cfg, reviewOptions)
+}
 
+// runReview carries out the review of a loaded project configuration, from the Zotero download and the
+// input conversion to the model queries, with logging already set up by the caller.
+func runReview(config *config.Config, reviewOptions ReviewOptions) error {
 	// Zotero review logic
 	if config.Project.Zotero.User != "" {
-		client := &http.Client{}
 		// downlaod pdfs
-		err := zotero.DownloadPDFs(client, config.Project.Zotero.User, config.Project.Zotero.API, config.Project.Zotero.Group, getDirectoryPath(config.Project.Configuration.ResultsFileName))
+		err := downloadZoteroPDFs(config)
 		if err != nil {
 			log.Printf("Error:\n%v", err)
 			return err
 		}
 		// convert pdfs
-		err = convert.Convert(getDirectoryPath(config.Project.Configuration.ResultsFileName)+"/zotero", "pdf")
+		err = convert.ConvertWithOptions(getDirectoryPath(config.Project.Configuration.ResultsFileName)+"/zotero", "pdf", conversionOptions(config, reviewOptions.ConvertOutputDir))
 		if err != nil {
 			log.Printf("Error:\n%v", err)
 			exit(ExitCodeErrorInReviewLogic)
 		}
+		if reviewOptions.ConvertOutputDir != "" {
+			config.Project.Configuration.InputDirectory = reviewOptions.ConvertOutputDir
+		}
 	} else {
 		// run input conversion if needed and not a Zotero project
 		if config.Project.Configuration.InputConversion != "no" {
-			err := convert.Convert(config.Project.Configuration.InputDirectory, config.Project.Configuration.InputConversion)
+			err := convert.ConvertWithOptions(config.Project.Configuration.InputDirectory, config.Project.Configuration.InputConversion, conversionOptions(config, reviewOptions.ConvertOutputDir))
 			if err != nil {
 				log.Printf("Error:\n%v", err)
 				exit(ExitCodeErrorInReviewLogic)
 			}
+			if reviewOptions.ConvertOutputDir != "" {
+				config.Project.Configuration.InputDirectory = reviewOptions.ConvertOutputDir
+			}
 		}
 	}
 
@@ -297,6 +338,43 @@ func waitWithStatus(waitTime int) {
 	}
 }
 
+// downloadZoteroPDFs downloads the PDFs of the Zotero collection or group in the project configuration to the zotero
+// subdirectory of the results directory, saving the JSON download summary there too if requested
+func downloadZoteroPDFs(cfg *config.Config) error {
+	parentDir := getDirectoryPath(cfg.Project.Configuration.ResultsFileName)
+	client, err := zotero.NewAuthClient(&http.Client{}, cfg.Project.Zotero.AuthMode)
+	if err != nil {
+		return err
+	}
+	downloadOptions := zotero.DefaultDownloadOptions()
+	downloadOptions.SkipDuplicates = true // the same paper in several collections is reviewed once
+	summary, err := zotero.DownloadPDFsWithOptions(client, cfg.Project.Zotero.User, cfg.Project.Zotero.API, cfg.Project.Zotero.Group, parentDir, downloadOptions)
+	if err != nil {
+		return err
+	}
+	if cfg.Project.Zotero.DownloadSummary == "yes" {
+		summaryPath := filepath.Join(parentDir, zotero.SummaryFileName)
+		if err := summary.WriteJSON(summaryPath); err != nil {
+			return err
+		}
+		log.Println("Zotero download summary saved to", summaryPath)
+	}
+	return nil
+}
+
+// conversionOptions returns the conversion options set in [project.configuration], writing the text files
+// to outputDir, or next to the sources when outputDir is ""
+func conversionOptions(cfg *config.Config, outputDir string) convert.Options {
+	return convert.Options{
+		PptxNotes:     cfg.Project.Configuration.PptxNotes == "yes",
+		MaxPages:      cfg.Project.Configuration.MaxPages,
+		PageMarkers:   cfg.Project.Configuration.PageMarkers == "yes",
+		ExtractTables: cfg.Project.Configuration.ExtractTables == "yes",
+		ExtractLinks:  cfg.Project.Configuration.ExtractLinks == "yes",
+		OutputDir:     outputDir,
+	}
+}
+
 func getDirectoryPath(resultsFileName string) string {
 	dir := filepath.Dir(resultsFileName)
 
@@ -5,6 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/open-and-sustainable/prismaid/config"
+	"github.com/open-and-sustainable/prismaid/convert"
 )
 
 const mockConfigDataTemplate = `
@@ -101,3 +104,19 @@ func TestRunReviewWithTempFiles(t *testing.T) {
 		t.Fatalf("Failed to clean up the output file: %v", err)
 	}
 }
+
+func TestConversionOptions(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Project.Configuration.PptxNotes = "yes"
+	cfg.Project.Configuration.MaxPages = 3
+	cfg.Project.Configuration.PageMarkers = "no"
+	cfg.Project.Configuration.ExtractTables = "yes"
+	cfg.Project.Configuration.ExtractLinks = "yes"
+
+	options := conversionOptions(cfg, "converted")
+	expected := convert.Options{PptxNotes: true, MaxPages: 3, ExtractTables: true, ExtractLinks: true, OutputDir: "converted"}
+	if options.PptxNotes != expected.PptxNotes || options.MaxPages != expected.MaxPages || options.PageMarkers != expected.PageMarkers ||
+		options.ExtractTables != expected.ExtractTables || options.ExtractLinks != expected.ExtractLinks || options.OutputDir != expected.OutputDir {
+		t.Errorf("conversionOptions() = %+v, want %+v", options, expected)
+	}
+}
@@ -0,0 +1,22 @@
+package prismaid
+
+import (
+	"github.com/open-and-sustainable/prismaid/tokens"
+)
+
+// EstimateTokens approximates the number of tokens in a text for the given provider (e.g., "OpenAI",
+// "GoogleAI", "Cohere", "Anthropic") without calling the provider's API.
+//
+// The estimate divides the number of characters by an average number of characters per token, about 4
+// for most providers. It is meant for sizing and rough cost estimates, and may differ from the count
+// reported by the provider. The ratios can be tuned through tokens.CharsPerToken.
+//
+// Parameters:
+//   - text: The text to be analyzed.
+//   - provider: The name of the AI provider.
+//
+// Returns:
+//   - The estimated number of tokens.
+func EstimateTokens(text string, provider string) int {
+	return tokens.EstimateTokens(text, provider)
+}
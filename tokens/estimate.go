@@ -0,0 +1,42 @@
+package tokens
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// DefaultCharsPerToken is the average number of characters per token assumed for providers
+// not listed in CharsPerToken.
+const DefaultCharsPerToken = 4.0
+
+// CharsPerToken holds the average number of characters per token used by EstimateTokens for each provider.
+// Entries can be changed or added to tune the approximation to a given corpus.
+var CharsPerToken = map[string]float64{
+	"OpenAI":    4.0,
+	"GoogleAI":  4.0,
+	"Cohere":    4.0,
+	"Anthropic": 3.5,
+}
+
+// EstimateTokens approximates the number of tokens in a text for the given provider, dividing the
+// number of characters by the provider's average characters per token.
+// Unlike RealTokenCounter it needs neither an API key nor network access, but the result is only an
+// approximation and may differ from the count reported by the provider.
+//
+// Arguments:
+//   - text: The text to be analyzed.
+//   - provider: The name of the AI provider, such as "OpenAI", "Cohere", or "GoogleAI".
+//
+// Returns:
+//   - An integer with the estimated number of tokens, zero for an empty text.
+func EstimateTokens(text string, provider string) int {
+	chars := utf8.RuneCountInString(text)
+	if chars == 0 {
+		return 0
+	}
+	ratio, ok := CharsPerToken[provider]
+	if !ok || ratio <= 0 {
+		ratio = DefaultCharsPerToken
+	}
+	return int(math.Ceil(float64(chars) / ratio))
+}
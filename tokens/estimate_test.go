@@ -0,0 +1,71 @@
+package tokens
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+    tests := []struct {
+        name     string
+        text     string
+        provider string
+        want     int
+    }{
+        {
+            name:     "Empty text",
+            text:     "",
+            provider: "OpenAI",
+            want:     0,
+        },
+        {
+            name:     "OpenAI ratio",
+            text:     strings.Repeat("a", 400),
+            provider: "OpenAI",
+            want:     100,
+        },
+        {
+            name:     "Anthropic ratio",
+            text:     strings.Repeat("a", 700),
+            provider: "Anthropic",
+            want:     200,
+        },
+        {
+            name:     "Unknown provider uses default ratio",
+            text:     strings.Repeat("a", 10),
+            provider: "UnknownAI",
+            want:     3,
+        },
+        {
+            name:     "Characters are counted, not bytes",
+            text:     strings.Repeat("é", 8),
+            provider: "OpenAI",
+            want:     2,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := EstimateTokens(tt.text, tt.provider)
+            if got != tt.want {
+                t.Errorf("EstimateTokens() = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestEstimateTokensOverride(t *testing.T) {
+    original, existed := CharsPerToken["Cohere"]
+    defer func() {
+        if existed {
+            CharsPerToken["Cohere"] = original
+        } else {
+            delete(CharsPerToken, "Cohere")
+        }
+    }()
+
+    CharsPerToken["Cohere"] = 2
+    if got := EstimateTokens(strings.Repeat("a", 10), "Cohere"); got != 5 {
+        t.Errorf("EstimateTokens() with overridden ratio = %v, want 5", got)
+    }
+}
@@ -4,13 +4,16 @@ import (
     "encoding/json"
     "fmt"
     "log"
-    "io"
     "net/http"
     "os"
     "path/filepath"
+    "strconv"
     "strings"
 )
 
+// groupIDPrefix introduces a numeric group ID in place of a group name, e.g. "group:123456/Collection"
+const groupIDPrefix = "group:"
+
 
 type HttpClient interface {
     Do(req *http.Request) (*http.Response, error)
@@ -19,18 +22,46 @@ type HttpClient interface {
 type Item struct {
     Key  string `json:"key"`
     Data struct {
-        Filename string `json:"filename"`
+        Title       string `json:"title"`
+        Filename    string `json:"filename"`
+        ContentType string `json:"contentType"`
     } `json:"data"`
 }
 
 // DownloadPDFs downloads all PDFs from the specified Zotero group or collection
 func DownloadPDFs(client HttpClient, username, apiKey, collectionName, parentDir string) error {
+    _, err := DownloadPDFsWithOptions(client, username, apiKey, collectionName, parentDir, DefaultDownloadOptions())
+    return err
+}
+
+// DownloadPDFsWithOptions downloads the attachments from the specified Zotero group or collection,
+// as DownloadPDFs does, applying the given options (e.g., the attachment types to fetch).
+// It returns a summary of the downloaded and failed attachments, e.g. to be saved with DownloadSummary.WriteJSON.
+func DownloadPDFsWithOptions(client HttpClient, username, apiKey, collectionName, parentDir string, options DownloadOptions) (DownloadSummary, error) {
     const baseURL = "https://api.zotero.org"
     userID := username
 
+    if err := VerifyCredentials(client, username, apiKey); err != nil {
+        return DownloadSummary{}, err
+    }
+
+    filenamePrefix := ""
+    if options.PrefixCollection {
+        filenamePrefix = collectionFilenamePrefix(collectionName)
+    }
+
+    // A "group:<id>" prefix targets a group library by its numeric ID, without looking up its name
+    if strings.HasPrefix(collectionName, groupIDPrefix) {
+        groupID, collectionPath, err := parseGroupIDPath(collectionName)
+        if err != nil {
+            return DownloadSummary{}, err
+        }
+        return downloadPDFsFromGroupID(client, groupID, apiKey, collectionPath, parentDir, filenamePrefix, options)
+    }
+
     collectionKey, err := getCollectionKey(client, username, apiKey, collectionName)
     if err != nil {
-        return downloadPDFsFromGroup(client, username, apiKey, collectionName, parentDir)
+        return downloadPDFsFromGroup(client, username, apiKey, collectionName, parentDir, filenamePrefix, options)
     } else {
         log.Println("Collection key:", collectionKey)
     }
@@ -39,68 +70,69 @@ func DownloadPDFs(client HttpClient, username, apiKey, collectionName, parentDir
     collectionURL := fmt.Sprintf("%s/users/%s/collections/%s/items?format=json&itemType=attachment", baseURL, userID, collectionKey)
     req, err := http.NewRequest("GET", collectionURL, nil)
     if err != nil {
-        return fmt.Errorf("error creating request: %v", err)
+        return DownloadSummary{}, fmt.Errorf("error creating request: %v", err)
     }
 
     req.Header.Add("Zotero-API-Key", apiKey)
     resp, err := client.Do(req)
     if err != nil {
-        return fmt.Errorf("error making request: %v", err)
+        return DownloadSummary{}, fmt.Errorf("error making request: %v", err)
     }
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return fmt.Errorf("error: received non-200 response status: %s", resp.Status)
+        return DownloadSummary{}, fmt.Errorf("error: received non-200 response status: %s", resp.Status)
     }
 
     var items []Item
     if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
-        return fmt.Errorf("error decoding JSON: %v", err)
+        return DownloadSummary{}, fmt.Errorf("error decoding JSON: %v", err)
     }
 
     outputDir := parentDir + "/zotero"
     if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
-        return fmt.Errorf("error creating directory: %v", err)
+        return DownloadSummary{}, fmt.Errorf("error creating directory: %v", err)
     }
 
-    for _, item := range items {
-        downloadURL := fmt.Sprintf("%s/users/%s/items/%s/file", baseURL, userID, item.Key)
-        req, err := http.NewRequest("GET", downloadURL, nil)
-        if err != nil {
-            log.Printf("Error creating request for file: %v\n", err)
-            continue
-        }
-        req.Header.Add("Zotero-API-Key", apiKey)
+    return downloadAttachments(client, apiKey, fmt.Sprintf("%s/users/%s", baseURL, userID), items, outputDir, filenamePrefix, options), nil
+}
 
-        resp, err := client.Do(req)
-        if err != nil {
-            log.Printf("Error downloading file: %v\n", err)
-            continue
-        }
-        defer resp.Body.Close()
+type keyInfo struct {
+    UserID int `json:"userID"`
+}
 
-        if resp.StatusCode != http.StatusOK {
-            log.Printf("Error: received non-200 response status for file: %s\n", resp.Status)
-            continue
-        }
+// VerifyCredentials checks that the API key is valid and belongs to the given user ID, so that
+// wrong credentials are reported before any download starts.
+func VerifyCredentials(client HttpClient, username, apiKey string) error {
+    const baseURL = "https://api.zotero.org"
 
-        outputPath := filepath.Join(outputDir, item.Data.Filename)
-        outFile, err := os.Create(outputPath)
-        if err != nil {
-            log.Printf("Error creating file: %v\n", err)
-            continue
-        }
-        defer outFile.Close()
+    req, err := http.NewRequest("GET", baseURL+"/keys/current", nil)
+    if err != nil {
+        return fmt.Errorf("error creating request: %v", err)
+    }
+    req.Header.Add("Zotero-API-Key", apiKey)
 
-        _, err = io.Copy(outFile, resp.Body)
-        if err != nil {
-            log.Printf("Error saving file: %v\n", err)
-            continue
-        }
+    resp, err := client.Do(req)
+    if err != nil {
+        return fmt.Errorf("error making request: %v", err)
+    }
+    defer resp.Body.Close()
 
-        log.Println("Downloaded:", item.Data.Filename)
+    switch resp.StatusCode {
+    case http.StatusOK:
+    case http.StatusForbidden, http.StatusNotFound:
+        return fmt.Errorf("invalid Zotero API key (%s): check the key at https://www.zotero.org/settings/keys", resp.Status)
+    default:
+        return fmt.Errorf("error verifying Zotero credentials: received non-200 response status: %s", resp.Status)
     }
 
+    var key keyInfo
+    if err := json.NewDecoder(resp.Body).Decode(&key); err != nil {
+        return fmt.Errorf("error decoding JSON: %v", err)
+    }
+    if fmt.Sprintf("%d", key.UserID) != username {
+        return fmt.Errorf("the Zotero API key belongs to user ID %d, not to the configured user '%s': use the numeric user ID shown at https://www.zotero.org/settings/keys", key.UserID, username)
+    }
     return nil
 }
 
@@ -195,14 +227,14 @@ func findCollectionByParent(collections []Collection, parentKey string, name str
     return result, nil
 }
 
-func downloadPDFsFromGroup(client HttpClient, username, apiKey, collectionName, parentDir string) error {
+func downloadPDFsFromGroup(client HttpClient, username, apiKey, collectionName, parentDir, filenamePrefix string, options DownloadOptions) (DownloadSummary, error) {
     const baseURL = "https://api.zotero.org"
     userID := username
 
     // Split collectionName into parts
     pathParts := strings.Split(collectionName, "/")
     if len(pathParts) == 0 {
-        return fmt.Errorf("collectionName is empty")
+        return DownloadSummary{}, fmt.Errorf("collectionName is empty")
     }
 
     groupName := pathParts[0]
@@ -212,23 +244,23 @@ func downloadPDFsFromGroup(client HttpClient, username, apiKey, collectionName,
     groupsURL := fmt.Sprintf("%s/users/%s/groups?format=json", baseURL, userID)
     req, err := http.NewRequest("GET", groupsURL, nil)
     if err != nil {
-        return fmt.Errorf("error creating request: %v", err)
+        return DownloadSummary{}, fmt.Errorf("error creating request: %v", err)
     }
     req.Header.Add("Zotero-API-Key", apiKey)
 
     resp, err := client.Do(req)
     if err != nil {
-        return fmt.Errorf("error making request: %v", err)
+        return DownloadSummary{}, fmt.Errorf("error making request: %v", err)
     }
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return fmt.Errorf("error: received non-200 response status: %s", resp.Status)
+        return DownloadSummary{}, fmt.Errorf("error: received non-200 response status: %s", resp.Status)
     }
 
     var groups []Group
     if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
-        return fmt.Errorf("error decoding JSON: %v", err)
+        return DownloadSummary{}, fmt.Errorf("error decoding JSON: %v", err)
     }
 
     // Find the group with the matching name
@@ -244,18 +276,40 @@ func downloadPDFsFromGroup(client HttpClient, username, apiKey, collectionName,
     }
 
     if !groupFound {
-        return fmt.Errorf("group '%s' not found", groupName)
+        return DownloadSummary{}, fmt.Errorf("group '%s' not found", groupName)
+    }
+
+    return downloadPDFsFromGroupID(client, groupID, apiKey, collectionPath, parentDir, filenamePrefix, options)
+}
+
+// parseGroupIDPath splits a "group:<id>/Collection/SubCollection" path into the numeric group ID and the collection path
+func parseGroupIDPath(collectionName string) (string, string, error) {
+    pathParts := strings.SplitN(strings.TrimPrefix(collectionName, groupIDPrefix), "/", 2)
+    groupID := pathParts[0]
+    if _, err := strconv.Atoi(groupID); err != nil || groupID == "" {
+        return "", "", fmt.Errorf("invalid group ID '%s': expected a number as in 'group:123456/Collection'", groupID)
+    }
+    collectionPath := ""
+    if len(pathParts) == 2 {
+        collectionPath = pathParts[1]
     }
+    return groupID, collectionPath, nil
+}
+
+// downloadPDFsFromGroupID downloads the PDFs of a group library, or of one of its collections if collectionPath is not empty
+func downloadPDFsFromGroupID(client HttpClient, groupID, apiKey, collectionPath, parentDir, filenamePrefix string, options DownloadOptions) (DownloadSummary, error) {
+    const baseURL = "https://api.zotero.org"
 
     // If collectionPath is empty, download items from the group's library root
     var collectionKey string
     if collectionPath != "" {
         // Find the collection within the group
+        var err error
         collectionKey, err = getGroupCollectionKey(client, groupID, apiKey, collectionPath)
         if err != nil {
-            return err
+            return DownloadSummary{}, err
         } else {
-            log.Printf("Collection key found in group '%s': %s", groupName, collectionKey)
+            log.Printf("Collection key found in group '%s': %s", groupID, collectionKey)
         }
     }
 
@@ -269,71 +323,33 @@ func downloadPDFsFromGroup(client HttpClient, username, apiKey, collectionName,
         itemsURL = fmt.Sprintf("%s/groups/%s/items?format=json&itemType=attachment", baseURL, groupID)
     }
 
-    req, err = http.NewRequest("GET", itemsURL, nil)
+    req, err := http.NewRequest("GET", itemsURL, nil)
     if err != nil {
-        return fmt.Errorf("error creating request: %v", err)
+        return DownloadSummary{}, fmt.Errorf("error creating request: %v", err)
     }
     req.Header.Add("Zotero-API-Key", apiKey)
 
-    resp, err = client.Do(req)
+    resp, err := client.Do(req)
     if err != nil {
-        return fmt.Errorf("error making request: %v", err)
+        return DownloadSummary{}, fmt.Errorf("error making request: %v", err)
     }
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return fmt.Errorf("error: received non-200 response status: %s", resp.Status)
+        return DownloadSummary{}, fmt.Errorf("error: received non-200 response status: %s", resp.Status)
     }
 
     var items []Item
     if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
-        return fmt.Errorf("error decoding JSON: %v", err)
+        return DownloadSummary{}, fmt.Errorf("error decoding JSON: %v", err)
     }
 
     outputDir := filepath.Join(parentDir, "zotero")
     if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
-        return fmt.Errorf("error creating directory: %v", err)
-    }
-
-    for _, item := range items {
-        downloadURL := fmt.Sprintf("%s/groups/%s/items/%s/file", baseURL, groupID, item.Key)
-        req, err := http.NewRequest("GET", downloadURL, nil)
-        if err != nil {
-            log.Printf("Error creating request for file: %v\n", err)
-            continue
-        }
-        req.Header.Add("Zotero-API-Key", apiKey)
-
-        resp, err := client.Do(req)
-        if err != nil {
-            log.Printf("Error downloading file: %v\n", err)
-            continue
-        }
-        defer resp.Body.Close()
-
-        if resp.StatusCode != http.StatusOK {
-            log.Printf("Error: received non-200 response status for file: %s\n", resp.Status)
-            continue
-        }
-
-        outputPath := filepath.Join(outputDir, item.Data.Filename)
-        outFile, err := os.Create(outputPath)
-        if err != nil {
-            log.Printf("Error creating file: %v\n", err)
-            continue
-        }
-        defer outFile.Close()
-
-        _, err = io.Copy(outFile, resp.Body)
-        if err != nil {
-            log.Printf("Error saving file: %v\n", err)
-            continue
-        }
-
-        log.Println("Downloaded:", item.Data.Filename)
+        return DownloadSummary{}, fmt.Errorf("error creating directory: %v", err)
     }
 
-    return nil // Successfully downloaded from group
+    return downloadAttachments(client, apiKey, fmt.Sprintf("%s/groups/%s", baseURL, groupID), items, outputDir, filenamePrefix, options), nil // Successfully downloaded from group
 }
 
 func getGroupCollectionKey(client HttpClient, groupID, apiKey, collectionPath string) (string, error) {
@@ -73,6 +73,14 @@ func TestDownloadPDFs(t *testing.T) {
                     }
                     urlPath := req.URL.Path
 
+                    // Handle API key verification request
+                    if urlPath == "/keys/current" {
+                        return &http.Response{
+                            StatusCode: http.StatusOK,
+                            Body:       io.NopCloser(bytes.NewBufferString(`{"key":"api_key", "userID":123}`)),
+                            Header:     make(http.Header),
+                        }, nil
+                    }
                     // Handle user's collections request
                     if strings.Contains(urlPath, "/users/") && strings.Contains(urlPath, "/collections") && !strings.Contains(urlPath, "/items") {
                         return &http.Response{
@@ -125,7 +133,146 @@ func TestDownloadPDFs(t *testing.T) {
             // Use t.TempDir() to create a temporary directory
             tempDir := t.TempDir()
 
-            err := DownloadPDFs(client, "user", "api_key", tc.collectionName, tempDir)
+            err := DownloadPDFs(client, "123", "api_key", tc.collectionName, tempDir)
+            if tc.expectedError != "" {
+                if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+                    t.Errorf("expected error %v, got %v", tc.expectedError, err)
+                }
+            } else if err != nil {
+                t.Errorf("expected no error, got %v", err)
+            }
+        })
+    }
+}
+
+
+func TestVerifyCredentials(t *testing.T) {
+    tests := []struct {
+        name          string
+        username      string
+        statusCode    int
+        body          string
+        expectedError string
+    }{
+        {
+            name:       "valid key for user",
+            username:   "123",
+            statusCode: http.StatusOK,
+            body:       `{"key":"api_key", "userID":123}`,
+        },
+        {
+            name:          "key of another user",
+            username:      "456",
+            statusCode:    http.StatusOK,
+            body:          `{"key":"api_key", "userID":123}`,
+            expectedError: "belongs to user ID 123",
+        },
+        {
+            name:          "invalid key",
+            username:      "123",
+            statusCode:    http.StatusForbidden,
+            body:          `Forbidden`,
+            expectedError: "invalid Zotero API key",
+        },
+        {
+            name:          "server error",
+            username:      "123",
+            statusCode:    http.StatusInternalServerError,
+            body:          ``,
+            expectedError: "non-200 response status",
+        },
+    }
+
+    for _, tc := range tests {
+        t.Run(tc.name, func(t *testing.T) {
+            client := &MockClient{
+                DoFunc: func(req *http.Request) (*http.Response, error) {
+                    if req.URL.Path != "/keys/current" {
+                        t.Errorf("unexpected request to %s", req.URL.Path)
+                    }
+                    if req.Header.Get("Zotero-API-Key") != "api_key" {
+                        t.Errorf("expected Zotero-API-Key header to be set")
+                    }
+                    return &http.Response{
+                        StatusCode: tc.statusCode,
+                        Status:     http.StatusText(tc.statusCode),
+                        Body:       io.NopCloser(bytes.NewBufferString(tc.body)),
+                        Header:     make(http.Header),
+                    }, nil
+                },
+            }
+
+            err := VerifyCredentials(client, tc.username, "api_key")
+            if tc.expectedError != "" {
+                if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+                    t.Errorf("expected error %v, got %v", tc.expectedError, err)
+                }
+            } else if err != nil {
+                t.Errorf("expected no error, got %v", err)
+            }
+        })
+    }
+}
+
+func TestDownloadPDFsByGroupID(t *testing.T) {
+    tests := []struct {
+        name           string
+        collectionName string
+        expectedPaths  []string
+        expectedError  string
+    }{
+        {
+            name:           "group library root",
+            collectionName: "group:789",
+            expectedPaths:  []string{"/keys/current", "/groups/789/items", "/groups/789/items/def/file"},
+        },
+        {
+            name:           "collection in group",
+            collectionName: "group:789/collection",
+            expectedPaths:  []string{"/keys/current", "/groups/789/collections", "/groups/789/collections/456/items", "/groups/789/items/def/file"},
+        },
+        {
+            name:           "invalid group ID",
+            collectionName: "group:TestGroup/collection",
+            expectedPaths:  []string{"/keys/current"},
+            expectedError:  "invalid group ID",
+        },
+    }
+
+    for _, tc := range tests {
+        t.Run(tc.name, func(t *testing.T) {
+            var requestedPaths []string
+            client := &MockClient{
+                DoFunc: func(req *http.Request) (*http.Response, error) {
+                    urlPath := req.URL.Path
+                    requestedPaths = append(requestedPaths, urlPath)
+                    body := ""
+                    switch {
+                    case urlPath == "/keys/current":
+                        body = `{"key":"api_key", "userID":123}`
+                    case urlPath == "/groups/789/collections":
+                        body = `[{"key":"456", "data":{"key":"456", "name":"collection", "parentCollection":false}}]`
+                    case strings.HasSuffix(urlPath, "/file"):
+                        body = "PDF content"
+                    case strings.HasSuffix(urlPath, "/items"):
+                        body = `[{"key":"def", "data":{"filename":"group_file.pdf"}}]`
+                    default:
+                        return &http.Response{
+                            StatusCode: http.StatusNotFound,
+                            Body:       io.NopCloser(bytes.NewBufferString(``)),
+                            Header:     make(http.Header),
+                        }, nil
+                    }
+                    return &http.Response{
+                        StatusCode: http.StatusOK,
+                        Body:       io.NopCloser(bytes.NewBufferString(body)),
+                        Header:     make(http.Header),
+                    }, nil
+                },
+            }
+
+            tempDir := t.TempDir()
+            err := DownloadPDFs(client, "123", "api_key", tc.collectionName, tempDir)
             if tc.expectedError != "" {
                 if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
                     t.Errorf("expected error %v, got %v", tc.expectedError, err)
@@ -133,6 +280,10 @@ func TestDownloadPDFs(t *testing.T) {
             } else if err != nil {
                 t.Errorf("expected no error, got %v", err)
             }
+
+            if strings.Join(requestedPaths, ",") != strings.Join(tc.expectedPaths, ",") {
+                t.Errorf("expected requests %v, got %v", tc.expectedPaths, requestedPaths)
+            }
         })
     }
 }
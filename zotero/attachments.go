@@ -0,0 +1,194 @@
+package zotero
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io"
+    "log"
+    "mime"
+    "net/http"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+)
+
+// DownloadOptions defines optional settings for the download of attachments
+type DownloadOptions struct {
+    // AttachmentTypes lists the attachments to download, as MIME types (e.g., "application/pdf",
+    // "application/epub+zip") or file extensions (e.g., "pdf", "epub"). "*" downloads all attachments.
+    AttachmentTypes []string
+    // SkipDuplicates removes the downloaded files that are byte-identical to a file already downloaded,
+    // e.g. the same paper stored in several collections, recording them as duplicates in the summary.
+    SkipDuplicates bool
+    // PrefixCollection prepends the sanitized collection path to the downloaded file names, e.g.
+    // "Group_SubCollection_paper.pdf", keeping track of the provenance of files saved in a single folder.
+    PrefixCollection bool
+}
+
+// DefaultDownloadOptions returns the options used by DownloadPDFs, downloading PDF attachments only
+// and keeping duplicates
+func DefaultDownloadOptions() DownloadOptions {
+    return DownloadOptions{
+        AttachmentTypes: []string{"application/pdf"},
+    }
+}
+
+// downloadAttachments downloads the files of the given attachment items into outputDir, prepending
+// filenamePrefix to their file names. A file name already taken by another attachment or by an existing file
+// with different content gets a numeric suffix, e.g. "paper_1.pdf".
+// libraryURL is the URL of the user or group library the items belong to.
+// Items not matching the attachment types in options are skipped, errors on single files are logged and skipped.
+// The returned summary lists the downloaded, the duplicate and the failed attachments.
+func downloadAttachments(client HttpClient, apiKey, libraryURL string, items []Item, outputDir, filenamePrefix string, options DownloadOptions) DownloadSummary {
+    summary := DownloadSummary{Downloaded: []DownloadedItem{}, Duplicates: []DuplicateItem{}, Failed: []FailedItem{}}
+    downloadedByHash := make(map[string]string) // content hash -> file name of the copy kept
+    usedFilenames := make(map[string]bool)      // file names written by this download
+    for _, item := range items {
+        if !matchesAttachmentTypes(item, options.AttachmentTypes) {
+            log.Printf("Skipping attachment '%s' of type '%s'\n", item.Data.Filename, item.Data.ContentType)
+            continue
+        }
+        filename := filenamePrefix + item.Data.Filename
+        tempPath, size, hash, err := downloadAttachment(client, apiKey, libraryURL, item, outputDir)
+        if err != nil {
+            log.Printf("Error: %v\n", err)
+            summary.Failed = append(summary.Failed, FailedItem{item.Key, item.Data.Title, filename, err.Error()})
+            continue
+        }
+        if options.SkipDuplicates {
+            // duplicates are discarded before reaching their file name, which may belong to a file already kept
+            if original, found := downloadedByHash[hash]; found {
+                if err := os.Remove(tempPath); err != nil {
+                    log.Printf("Error removing duplicate file: %v\n", err)
+                }
+                log.Printf("Skipping duplicate attachment '%s', identical to '%s'\n", filename, original)
+                summary.Duplicates = append(summary.Duplicates, DuplicateItem{item.Key, item.Data.Title, filename, original})
+                continue
+            }
+        }
+        if available := availableFilename(outputDir, filename, hash, usedFilenames); available != filename {
+            log.Printf("Saving attachment '%s' as '%s', the name is taken by a different file\n", filename, available)
+            filename = available
+        }
+        if err := os.Rename(tempPath, filepath.Join(outputDir, filename)); err != nil {
+            os.Remove(tempPath)
+            log.Printf("Error: %v\n", err)
+            summary.Failed = append(summary.Failed, FailedItem{item.Key, item.Data.Title, filename, fmt.Sprintf("error saving file: %v", err)})
+            continue
+        }
+        downloadedByHash[hash] = filename
+        usedFilenames[filename] = true
+        log.Println("Downloaded:", filename)
+        summary.Downloaded = append(summary.Downloaded, DownloadedItem{item.Key, item.Data.Title, filename, size})
+    }
+    return summary
+}
+
+// downloadAttachment saves the file of a single attachment item to a temporary file in outputDir, returning
+// the path of the temporary file, its size in bytes and the hex encoded SHA-256 hash of its content.
+// The caller moves the temporary file to its final name or removes it.
+func downloadAttachment(client HttpClient, apiKey, libraryURL string, item Item, outputDir string) (string, int64, string, error) {
+    downloadURL := fmt.Sprintf("%s/items/%s/file", libraryURL, item.Key)
+    req, err := http.NewRequest("GET", downloadURL, nil)
+    if err != nil {
+        return "", 0, "", fmt.Errorf("error creating request for file: %v", err)
+    }
+    req.Header.Add("Zotero-API-Key", apiKey)
+
+    resp, err := client.Do(req)
+    if err != nil {
+        return "", 0, "", fmt.Errorf("error downloading file: %v", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return "", 0, "", fmt.Errorf("received non-200 response status for file: %s", resp.Status)
+    }
+
+    outFile, err := os.CreateTemp(outputDir, ".download-*")
+    if err != nil {
+        return "", 0, "", fmt.Errorf("error creating file: %v", err)
+    }
+
+    hasher := sha256.New()
+    size, err := io.Copy(io.MultiWriter(outFile, hasher), resp.Body)
+    if closeErr := outFile.Close(); err == nil {
+        err = closeErr
+    }
+    if err != nil {
+        os.Remove(outFile.Name())
+        return "", size, "", fmt.Errorf("error saving file: %v", err)
+    }
+    return outFile.Name(), size, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// availableFilename returns filename, or the first of "name_1.ext", "name_2.ext", ... that is neither in used
+// nor the name of an existing file in outputDir with a content hash other than hash
+func availableFilename(outputDir, filename, hash string, used map[string]bool) string {
+    extension := filepath.Ext(filename)
+    base := strings.TrimSuffix(filename, extension)
+    candidate := filename
+    for i := 1; used[candidate] || conflictingFile(filepath.Join(outputDir, candidate), hash); i++ {
+        candidate = fmt.Sprintf("%s_%d%s", base, i, extension)
+    }
+    return candidate
+}
+
+// conflictingFile reports whether the file at path exists with a content hash other than hash
+func conflictingFile(path, hash string) bool {
+    file, err := os.Open(path)
+    if os.IsNotExist(err) {
+        return false
+    } else if err != nil {
+        return true
+    }
+    defer file.Close()
+
+    hasher := sha256.New()
+    if _, err := io.Copy(hasher, file); err != nil {
+        return true
+    }
+    return hex.EncodeToString(hasher.Sum(nil)) != hash
+}
+
+// collectionFilenamePrefix turns a collection path such as "Group/Sub Collection" or "group:123/Sub"
+// into a file name prefix such as "Group_Sub_Collection_", replacing unsafe characters with underscores
+func collectionFilenamePrefix(collectionPath string) string {
+    sanitized := strings.Trim(unsafeFilenameChars.ReplaceAllString(collectionPath, "_"), "_")
+    if sanitized == "" {
+        return ""
+    }
+    return sanitized + "_"
+}
+
+// unsafeFilenameChars matches the runs of characters replaced in file name prefixes
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// matchesAttachmentTypes reports whether the item is one of the given attachment types.
+// MIME types are compared with the item's content type, or with the type of its file extension
+// when the content type is missing; extensions are compared with the item's file name.
+func matchesAttachmentTypes(item Item, attachmentTypes []string) bool {
+    extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(item.Data.Filename), "."))
+    contentType := strings.ToLower(item.Data.ContentType)
+    if contentType == "" && extension != "" {
+        if mediaType, _, err := mime.ParseMediaType(mime.TypeByExtension("." + extension)); err == nil {
+            contentType = mediaType
+        }
+    }
+    for _, attachmentType := range attachmentTypes {
+        attachmentType = strings.ToLower(strings.TrimSpace(attachmentType))
+        switch {
+        case attachmentType == "*":
+            return true
+        case strings.Contains(attachmentType, "/"):
+            if attachmentType == contentType {
+                return true
+            }
+        case strings.TrimPrefix(attachmentType, ".") == extension:
+            return true
+        }
+    }
+    return false
+}
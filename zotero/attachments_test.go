@@ -0,0 +1,314 @@
+package zotero
+
+import (
+    "bytes"
+    "io"
+    "net/http"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "testing"
+)
+
+func newTestItem(key, filename, contentType string) Item {
+    item := Item{Key: key}
+    item.Data.Filename = filename
+    item.Data.ContentType = contentType
+    return item
+}
+
+func TestMatchesAttachmentTypes(t *testing.T) {
+    tests := []struct {
+        name            string
+        item            Item
+        attachmentTypes []string
+        want            bool
+    }{
+        {"PDF by MIME type", newTestItem("a", "paper.pdf", "application/pdf"), []string{"application/pdf"}, true},
+        {"PDF without content type", newTestItem("a", "paper.pdf", ""), []string{"application/pdf"}, true},
+        {"EPUB excluded by default", newTestItem("a", "book.epub", "application/epub+zip"), DefaultDownloadOptions().AttachmentTypes, false},
+        {"EPUB by MIME type", newTestItem("a", "book.epub", "application/epub+zip"), []string{"application/pdf", "application/epub+zip"}, true},
+        {"EPUB by extension", newTestItem("a", "book.EPUB", "application/epub+zip"), []string{"epub"}, true},
+        {"extension with dot", newTestItem("a", "data.zip", "application/zip"), []string{".zip"}, true},
+        {"snapshot excluded", newTestItem("a", "snapshot.html", "text/html"), []string{"pdf"}, false},
+        {"all attachments", newTestItem("a", "snapshot.html", "text/html"), []string{"*"}, true},
+        {"no types", newTestItem("a", "paper.pdf", "application/pdf"), nil, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := matchesAttachmentTypes(tt.item, tt.attachmentTypes); got != tt.want {
+                t.Errorf("matchesAttachmentTypes() = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestDownloadPDFsWithOptionsAttachmentTypes(t *testing.T) {
+    itemsResponse := `[
+        {"key":"a1", "data":{"filename":"paper.pdf", "contentType":"application/pdf"}},
+        {"key":"a2", "data":{"filename":"book.epub", "contentType":"application/epub+zip"}},
+        {"key":"a3", "data":{"filename":"snapshot.html", "contentType":"text/html"}}
+    ]`
+
+    tests := []struct {
+        name          string
+        options       DownloadOptions
+        expectedFiles []string
+    }{
+        {"default downloads PDFs only", DefaultDownloadOptions(), []string{"paper.pdf"}},
+        {"PDF and EPUB", DownloadOptions{AttachmentTypes: []string{"pdf", "epub"}}, []string{"book.epub", "paper.pdf"}},
+        {"all attachments", DownloadOptions{AttachmentTypes: []string{"*"}}, []string{"book.epub", "paper.pdf", "snapshot.html"}},
+    }
+
+    for _, tc := range tests {
+        t.Run(tc.name, func(t *testing.T) {
+            client := &MockClient{
+                DoFunc: func(req *http.Request) (*http.Response, error) {
+                    urlPath := req.URL.Path
+                    body := ""
+                    switch {
+                    case urlPath == "/keys/current":
+                        body = `{"key":"api_key", "userID":123}`
+                    case strings.HasSuffix(urlPath, "/collections"):
+                        body = `[{"key":"123", "data":{"key":"123", "name":"collection", "parentCollection":false}}]`
+                    case strings.HasSuffix(urlPath, "/file"):
+                        body = "file content"
+                    case strings.HasSuffix(urlPath, "/items"):
+                        body = itemsResponse
+                    }
+                    return &http.Response{
+                        StatusCode: http.StatusOK,
+                        Body:       io.NopCloser(bytes.NewBufferString(body)),
+                        Header:     make(http.Header),
+                    }, nil
+                },
+            }
+
+            tempDir := t.TempDir()
+            if _, err := DownloadPDFsWithOptions(client, "123", "api_key", "collection", tempDir, tc.options); err != nil {
+                t.Fatalf("expected no error, got %v", err)
+            }
+
+            entries, err := os.ReadDir(filepath.Join(tempDir, "zotero"))
+            if err != nil {
+                t.Fatalf("failed to read output directory: %v", err)
+            }
+            var files []string
+            for _, entry := range entries {
+                files = append(files, entry.Name())
+            }
+            sort.Strings(files)
+            if strings.Join(files, ",") != strings.Join(tc.expectedFiles, ",") {
+                t.Errorf("expected files %v, got %v", tc.expectedFiles, files)
+            }
+        })
+    }
+}
+
+func TestDownloadPDFsWithOptionsSkipDuplicates(t *testing.T) {
+    client := &MockClient{
+        DoFunc: func(req *http.Request) (*http.Response, error) {
+            urlPath := req.URL.Path
+            body := ""
+            switch {
+            case urlPath == "/keys/current":
+                body = `{"key":"api_key", "userID":123}`
+            case strings.HasSuffix(urlPath, "/collections"):
+                body = `[{"key":"123", "data":{"key":"123", "name":"collection", "parentCollection":false}}]`
+            case strings.HasSuffix(urlPath, "/a3/file"):
+                body = "other paper"
+            case strings.HasSuffix(urlPath, "/a5/file"), strings.HasSuffix(urlPath, "/a6/file"):
+                body = "third paper"
+            case strings.HasSuffix(urlPath, "/file"):
+                body = "same paper"
+            case strings.HasSuffix(urlPath, "/items"):
+                body = `[
+                    {"key":"a1", "data":{"title":"Paper", "filename":"paper.pdf", "contentType":"application/pdf"}},
+                    {"key":"a2", "data":{"title":"Paper copy", "filename":"paper-copy.pdf", "contentType":"application/pdf"}},
+                    {"key":"a3", "data":{"title":"Other paper", "filename":"other.pdf", "contentType":"application/pdf"}},
+                    {"key":"a4", "data":{"title":"Same name", "filename":"paper.pdf", "contentType":"application/pdf"}},
+                    {"key":"a5", "data":{"title":"Third paper", "filename":"third.pdf", "contentType":"application/pdf"}},
+                    {"key":"a6", "data":{"title":"Third paper under a kept name", "filename":"paper.pdf", "contentType":"application/pdf"}}
+                ]`
+            }
+            return &http.Response{
+                StatusCode: http.StatusOK,
+                Body:       io.NopCloser(bytes.NewBufferString(body)),
+                Header:     make(http.Header),
+            }, nil
+        },
+    }
+
+    tempDir := t.TempDir()
+    options := DownloadOptions{AttachmentTypes: []string{"application/pdf"}, SkipDuplicates: true}
+    summary, err := DownloadPDFsWithOptions(client, "123", "api_key", "collection", tempDir, options)
+    if err != nil {
+        t.Fatalf("expected no error, got %v", err)
+    }
+
+    entries, err := os.ReadDir(filepath.Join(tempDir, "zotero"))
+    if err != nil {
+        t.Fatalf("failed to read output directory: %v", err)
+    }
+    var files []string
+    for _, entry := range entries {
+        files = append(files, entry.Name())
+    }
+    sort.Strings(files)
+    if strings.Join(files, ",") != "other.pdf,paper.pdf,third.pdf" {
+        t.Errorf("expected files [other.pdf paper.pdf third.pdf], got %v", files)
+    }
+    // a duplicate named as a file already kept must not replace or remove it
+    if content, err := os.ReadFile(filepath.Join(tempDir, "zotero", "paper.pdf")); err != nil || string(content) != "same paper" {
+        t.Errorf("expected paper.pdf to keep its content, got %q (%v)", content, err)
+    }
+
+    expectedDuplicates := []DuplicateItem{
+        {Key: "a2", Title: "Paper copy", Filename: "paper-copy.pdf", DuplicateOf: "paper.pdf"},
+        {Key: "a4", Title: "Same name", Filename: "paper.pdf", DuplicateOf: "paper.pdf"},
+        {Key: "a6", Title: "Third paper under a kept name", Filename: "paper.pdf", DuplicateOf: "third.pdf"},
+    }
+    if len(summary.Duplicates) != len(expectedDuplicates) {
+        t.Fatalf("expected duplicates %+v, got %+v", expectedDuplicates, summary.Duplicates)
+    }
+    for i := range expectedDuplicates {
+        if summary.Duplicates[i] != expectedDuplicates[i] {
+            t.Errorf("expected duplicate %+v, got %+v", expectedDuplicates[i], summary.Duplicates[i])
+        }
+    }
+    if len(summary.Downloaded) != 3 {
+        t.Errorf("expected 3 downloaded items, got %+v", summary.Downloaded)
+    }
+}
+
+func TestCollectionFilenamePrefix(t *testing.T) {
+    tests := []struct {
+        collectionPath string
+        want           string
+    }{
+        {"Group/SubCollection", "Group_SubCollection_"},
+        {"My Group/Sub Collection", "My_Group_Sub_Collection_"},
+        {"group:123456/Reviews", "group_123456_Reviews_"},
+        {"collection", "collection_"},
+        {"/", ""},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.collectionPath, func(t *testing.T) {
+            if got := collectionFilenamePrefix(tt.collectionPath); got != tt.want {
+                t.Errorf("collectionFilenamePrefix(%q) = %q, want %q", tt.collectionPath, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestDownloadPDFsWithOptionsPrefixCollection(t *testing.T) {
+    client := &MockClient{
+        DoFunc: func(req *http.Request) (*http.Response, error) {
+            urlPath := req.URL.Path
+            body := ""
+            switch {
+            case urlPath == "/keys/current":
+                body = `{"key":"api_key", "userID":123}`
+            case strings.HasSuffix(urlPath, "/collections"):
+                body = `[{"key":"123", "data":{"key":"123", "name":"collection", "parentCollection":false}}]`
+            case strings.HasSuffix(urlPath, "/file"):
+                body = "file content"
+            case strings.HasSuffix(urlPath, "/items"):
+                body = `[{"key":"a1", "data":{"title":"Paper", "filename":"paper.pdf", "contentType":"application/pdf"}}]`
+            }
+            return &http.Response{
+                StatusCode: http.StatusOK,
+                Body:       io.NopCloser(bytes.NewBufferString(body)),
+                Header:     make(http.Header),
+            }, nil
+        },
+    }
+
+    options := DefaultDownloadOptions()
+    options.PrefixCollection = true
+    tempDir := t.TempDir()
+    summary, err := DownloadPDFsWithOptions(client, "123", "api_key", "collection", tempDir, options)
+    if err != nil {
+        t.Fatalf("expected no error, got %v", err)
+    }
+
+    if _, err := os.Stat(filepath.Join(tempDir, "zotero", "collection_paper.pdf")); err != nil {
+        t.Errorf("expected prefixed file collection_paper.pdf: %v", err)
+    }
+    if len(summary.Downloaded) != 1 || summary.Downloaded[0].Filename != "collection_paper.pdf" {
+        t.Errorf("expected the summary to list collection_paper.pdf, got %+v", summary.Downloaded)
+    }
+}
+
+func TestDownloadPDFsWithOptionsSameNameDifferentContent(t *testing.T) {
+    client := &MockClient{
+        DoFunc: func(req *http.Request) (*http.Response, error) {
+            urlPath := req.URL.Path
+            body := ""
+            switch {
+            case urlPath == "/keys/current":
+                body = `{"key":"api_key", "userID":123}`
+            case strings.HasSuffix(urlPath, "/collections"):
+                body = `[{"key":"123", "data":{"key":"123", "name":"collection", "parentCollection":false}}]`
+            case strings.HasSuffix(urlPath, "/a1/file"):
+                body = "first paper"
+            case strings.HasSuffix(urlPath, "/a2/file"):
+                body = "second paper"
+            case strings.HasSuffix(urlPath, "/a3/file"):
+                body = "kept paper"
+            case strings.HasSuffix(urlPath, "/items"):
+                body = `[
+                    {"key":"a1", "data":{"title":"First", "filename":"paper.pdf", "contentType":"application/pdf"}},
+                    {"key":"a2", "data":{"title":"Second", "filename":"paper.pdf", "contentType":"application/pdf"}},
+                    {"key":"a3", "data":{"title":"Kept", "filename":"kept.pdf", "contentType":"application/pdf"}}
+                ]`
+            }
+            return &http.Response{
+                StatusCode: http.StatusOK,
+                Body:       io.NopCloser(bytes.NewBufferString(body)),
+                Header:     make(http.Header),
+            }, nil
+        },
+    }
+
+    tempDir := t.TempDir()
+    outputDir := filepath.Join(tempDir, "zotero")
+    if err := os.MkdirAll(outputDir, 0755); err != nil {
+        t.Fatalf("failed to create output directory: %v", err)
+    }
+    // a file left by a previous download with a different content must not be replaced
+    if err := os.WriteFile(filepath.Join(outputDir, "paper.pdf"), []byte("previous paper"), 0644); err != nil {
+        t.Fatalf("failed to write existing file: %v", err)
+    }
+    // a file left by a previous download with the same content is written again under its name
+    if err := os.WriteFile(filepath.Join(outputDir, "kept.pdf"), []byte("kept paper"), 0644); err != nil {
+        t.Fatalf("failed to write existing file: %v", err)
+    }
+
+    summary, err := DownloadPDFsWithOptions(client, "123", "api_key", "collection", tempDir, DefaultDownloadOptions())
+    if err != nil {
+        t.Fatalf("expected no error, got %v", err)
+    }
+
+    expectedContents := map[string]string{
+        "paper.pdf":   "previous paper",
+        "paper_1.pdf": "first paper",
+        "paper_2.pdf": "second paper",
+        "kept.pdf":    "kept paper",
+    }
+    for filename, expected := range expectedContents {
+        if content, err := os.ReadFile(filepath.Join(outputDir, filename)); err != nil || string(content) != expected {
+            t.Errorf("expected %s to contain %q, got %q (%v)", filename, expected, content, err)
+        }
+    }
+    var filenames []string
+    for _, downloaded := range summary.Downloaded {
+        filenames = append(filenames, downloaded.Filename)
+    }
+    if strings.Join(filenames, ",") != "paper_1.pdf,paper_2.pdf,kept.pdf" {
+        t.Errorf("expected the summary to list the saved file names, got %v", filenames)
+    }
+}
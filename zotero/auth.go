@@ -0,0 +1,38 @@
+package zotero
+
+import (
+    "fmt"
+    "net/http"
+)
+
+// Authentication modes for the Zotero API credentials passed as apiKey
+const (
+    AuthAPIKey = "api_key" // the credentials are a private API key, sent in the Zotero-API-Key header (default)
+    AuthOAuth  = "oauth"   // the credentials are an OAuth token, sent in the "Authorization: Bearer" header
+)
+
+// NewAuthClient returns a client sending the credentials passed as apiKey to the functions of this package
+// according to authMode, AuthAPIKey (or "") or AuthOAuth. With AuthAPIKey the client is returned unchanged.
+func NewAuthClient(client HttpClient, authMode string) (HttpClient, error) {
+    switch authMode {
+    case "", AuthAPIKey:
+        return client, nil
+    case AuthOAuth:
+        return bearerClient{client}, nil
+    default:
+        return nil, fmt.Errorf("unsupported Zotero authentication mode '%s': expected '%s' or '%s'", authMode, AuthAPIKey, AuthOAuth)
+    }
+}
+
+// bearerClient moves the credentials of the Zotero-API-Key header to an OAuth bearer token
+type bearerClient struct {
+    client HttpClient
+}
+
+func (c bearerClient) Do(req *http.Request) (*http.Response, error) {
+    if token := req.Header.Get("Zotero-API-Key"); token != "" {
+        req.Header.Del("Zotero-API-Key")
+        req.Header.Set("Authorization", "Bearer "+token)
+    }
+    return c.client.Do(req)
+}
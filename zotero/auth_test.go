@@ -0,0 +1,72 @@
+package zotero
+
+import (
+    "bytes"
+    "io"
+    "net/http"
+    "strings"
+    "testing"
+)
+
+func TestNewAuthClientHeaders(t *testing.T) {
+    tests := []struct {
+        name                  string
+        authMode              string
+        expectedAPIKey        string
+        expectedAuthorization string
+    }{
+        {"default mode", "", "secret", ""},
+        {"API key mode", AuthAPIKey, "secret", ""},
+        {"OAuth mode", AuthOAuth, "", "Bearer secret"},
+    }
+
+    for _, tc := range tests {
+        t.Run(tc.name, func(t *testing.T) {
+            requests := 0
+            mock := &MockClient{
+                DoFunc: func(req *http.Request) (*http.Response, error) {
+                    requests++
+                    if got := req.Header.Get("Zotero-API-Key"); got != tc.expectedAPIKey {
+                        t.Errorf("%s: Zotero-API-Key header = %q, want %q", req.URL.Path, got, tc.expectedAPIKey)
+                    }
+                    if got := req.Header.Get("Authorization"); got != tc.expectedAuthorization {
+                        t.Errorf("%s: Authorization header = %q, want %q", req.URL.Path, got, tc.expectedAuthorization)
+                    }
+                    body := ""
+                    switch {
+                    case req.URL.Path == "/keys/current":
+                        body = `{"key":"secret", "userID":123}`
+                    case strings.HasSuffix(req.URL.Path, "/collections"):
+                        body = `[{"key":"123", "data":{"key":"123", "name":"collection", "parentCollection":false}}]`
+                    case strings.HasSuffix(req.URL.Path, "/file"):
+                        body = "file content"
+                    case strings.HasSuffix(req.URL.Path, "/items"):
+                        body = `[{"key":"a1", "data":{"filename":"paper.pdf", "contentType":"application/pdf"}}]`
+                    }
+                    return &http.Response{
+                        StatusCode: http.StatusOK,
+                        Body:       io.NopCloser(bytes.NewBufferString(body)),
+                        Header:     make(http.Header),
+                    }, nil
+                },
+            }
+
+            client, err := NewAuthClient(mock, tc.authMode)
+            if err != nil {
+                t.Fatalf("expected no error, got %v", err)
+            }
+            if err := DownloadPDFs(client, "123", "secret", "collection", t.TempDir()); err != nil {
+                t.Fatalf("expected no error, got %v", err)
+            }
+            if requests == 0 {
+                t.Errorf("expected requests to be sent through the client")
+            }
+        })
+    }
+}
+
+func TestNewAuthClientUnsupportedMode(t *testing.T) {
+    if _, err := NewAuthClient(&MockClient{}, "password"); err == nil {
+        t.Errorf("expected an error for an unsupported authentication mode")
+    }
+}
@@ -7,6 +7,7 @@
 // It leverages standard library components for HTTP requests and provides
 // utility functions to handle common tasks such as:
 //
+// - Verifying the API key and user ID before any download starts.
 // - Retrieving collection keys based on collection names, supporting nested structures.
 // - Downloading all PDFs from specified Zotero collections or shared groups, including nested collections.
 // - Automatically managing API request headers and response status codes.
@@ -23,6 +24,27 @@
 // can be specified in the same way, with the group name followed by any nested
 // collections.
 //
+// A group can also be referenced by its numeric ID, as found in the group URL, using the
+// `"group:<id>"` prefix, e.g. `"group:123456/Collection"`. This skips the lookup of the group
+// by name and disambiguates groups sharing the same name.
+//
+// **Attachment Types**
+//
+// By default only PDF attachments are downloaded. DownloadPDFsWithOptions accepts a
+// DownloadOptions value whose AttachmentTypes lists MIME types (e.g. `"application/epub+zip"`)
+// or file extensions (e.g. `"epub"`) to fetch; `"*"` downloads every attachment.
+//
+// With PrefixCollection, the downloaded file names start with the sanitized collection
+// path, e.g. `"Group_SubCollection_paper.pdf"`, keeping their provenance in a single folder.
+//
+// **Duplicate Attachments**
+//
+// The same paper stored in several collections can be downloaded once: with SkipDuplicates,
+// files byte-identical to one already downloaded are removed, logged, and listed in the
+// Duplicates of the DownloadSummary with the name of the copy kept. Different files with
+// the same name are saved with a numeric suffix, e.g. `"paper_1.pdf"`, instead of
+// replacing each other.
+//
 // **PDF Conversion and AI Review**
 //
 // After downloading PDFs from Zotero, the package automatically converts them into
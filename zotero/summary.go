@@ -0,0 +1,54 @@
+package zotero
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+)
+
+// SummaryFileName is the name of the JSON download summary written in the parent directory of the downloads
+const SummaryFileName = "zotero_download.json"
+
+// DownloadSummary is a machine-readable record of the attachments fetched from Zotero
+type DownloadSummary struct {
+    Downloaded []DownloadedItem `json:"downloaded"`
+    Duplicates []DuplicateItem  `json:"duplicates"`
+    Failed     []FailedItem     `json:"failed"`
+}
+
+// DownloadedItem describes an attachment saved to disk, with its size in bytes
+type DownloadedItem struct {
+    Key      string `json:"key"`
+    Title    string `json:"title"`
+    Filename string `json:"filename"`
+    Size     int64  `json:"size"`
+}
+
+// DuplicateItem describes an attachment removed after download because it is byte-identical to the file
+// of another attachment, kept under the name in DuplicateOf
+type DuplicateItem struct {
+    Key         string `json:"key"`
+    Title       string `json:"title"`
+    Filename    string `json:"filename"`
+    DuplicateOf string `json:"duplicate_of"`
+}
+
+// FailedItem describes an attachment that could not be downloaded, with the error encountered
+type FailedItem struct {
+    Key      string `json:"key"`
+    Title    string `json:"title"`
+    Filename string `json:"filename"`
+    Error    string `json:"error"`
+}
+
+// WriteJSON saves the summary as indented JSON to the file at path
+func (s DownloadSummary) WriteJSON(path string) error {
+    data, err := json.MarshalIndent(s, "", "  ")
+    if err != nil {
+        return fmt.Errorf("error encoding download summary: %v", err)
+    }
+    if err := os.WriteFile(path, data, 0644); err != nil {
+        return fmt.Errorf("error writing download summary: %v", err)
+    }
+    return nil
+}
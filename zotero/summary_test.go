@@ -0,0 +1,73 @@
+package zotero
+
+import (
+    "bytes"
+    "encoding/json"
+    "io"
+    "net/http"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+func TestDownloadPDFsWithOptionsSummary(t *testing.T) {
+    client := &MockClient{
+        DoFunc: func(req *http.Request) (*http.Response, error) {
+            urlPath := req.URL.Path
+            status := http.StatusOK
+            body := ""
+            switch {
+            case urlPath == "/keys/current":
+                body = `{"key":"api_key", "userID":123}`
+            case strings.HasSuffix(urlPath, "/collections"):
+                body = `[{"key":"123", "data":{"key":"123", "name":"collection", "parentCollection":false}}]`
+            case strings.HasSuffix(urlPath, "/a2/file"):
+                status = http.StatusNotFound
+            case strings.HasSuffix(urlPath, "/file"):
+                body = "file content"
+            case strings.HasSuffix(urlPath, "/items"):
+                body = `[
+                    {"key":"a1", "data":{"title":"Full Text PDF", "filename":"paper.pdf", "contentType":"application/pdf"}},
+                    {"key":"a2", "data":{"title":"Missing PDF", "filename":"missing.pdf", "contentType":"application/pdf"}}
+                ]`
+            }
+            return &http.Response{
+                StatusCode: status,
+                Status:     http.StatusText(status),
+                Body:       io.NopCloser(bytes.NewBufferString(body)),
+                Header:     make(http.Header),
+            }, nil
+        },
+    }
+
+    tempDir := t.TempDir()
+    summary, err := DownloadPDFsWithOptions(client, "123", "api_key", "collection", tempDir, DefaultDownloadOptions())
+    if err != nil {
+        t.Fatalf("expected no error, got %v", err)
+    }
+
+    expectedDownloaded := []DownloadedItem{{Key: "a1", Title: "Full Text PDF", Filename: "paper.pdf", Size: int64(len("file content"))}}
+    if len(summary.Downloaded) != 1 || summary.Downloaded[0] != expectedDownloaded[0] {
+        t.Errorf("expected downloaded items %+v, got %+v", expectedDownloaded, summary.Downloaded)
+    }
+    if len(summary.Failed) != 1 || summary.Failed[0].Key != "a2" || summary.Failed[0].Error == "" {
+        t.Errorf("expected one failed item with key a2 and an error, got %+v", summary.Failed)
+    }
+
+    summaryPath := filepath.Join(tempDir, SummaryFileName)
+    if err := summary.WriteJSON(summaryPath); err != nil {
+        t.Fatalf("WriteJSON returned an error: %v", err)
+    }
+    data, err := os.ReadFile(summaryPath)
+    if err != nil {
+        t.Fatalf("failed to read summary file: %v", err)
+    }
+    var decoded DownloadSummary
+    if err := json.Unmarshal(data, &decoded); err != nil {
+        t.Fatalf("summary file is not valid JSON: %v", err)
+    }
+    if len(decoded.Downloaded) != 1 || decoded.Downloaded[0] != expectedDownloaded[0] || len(decoded.Failed) != 1 {
+        t.Errorf("unexpected decoded summary: %+v", decoded)
+    }
+}